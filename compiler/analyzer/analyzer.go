@@ -0,0 +1,133 @@
+// Package analyzer implements Ferret's semantic analysis: type checking and
+// the assignability/castability rules the rest of the compiler relies on.
+package analyzer
+
+import (
+	"github.com/itsfuad/ferret-compiler/compiler/log"
+	"github.com/itsfuad/ferret-compiler/compiler/types"
+)
+
+// Analyzer holds the per-compilation state needed to type check a program.
+// A fresh Analyzer should be created for each compilation so caches don't
+// leak stale results across runs. There is no shared global state behind
+// New/NewWithOptions, so independent Analyzers — one per concurrent
+// compilation, e.g. parallel tests or concurrent LSP sessions typechecking
+// different files — can coexist and run concurrently without coordination.
+type Analyzer struct {
+	// Logger receives verbose, colorized tracing of analyzer decisions. It
+	// is nil (silent) by default because the trace output is far too noisy
+	// for normal builds — a caller that wants it attaches one via
+	// NewWithOptions.
+	Logger *log.Logger
+
+	castCache map[castKey]bool
+}
+
+// Options configures an Analyzer built by NewWithOptions.
+type Options struct {
+	Logger *log.Logger
+}
+
+// New creates an Analyzer ready to type check a single compilation unit,
+// with tracing silenced.
+func New() *Analyzer {
+	return NewWithOptions(Options{})
+}
+
+// NewWithOptions is New with tracing and any other Options configurable by
+// the caller.
+func NewWithOptions(opts Options) *Analyzer {
+	return &Analyzer{
+		Logger:    opts.Logger,
+		castCache: make(map[castKey]bool),
+	}
+}
+
+// castKey identifies an (target, source) pair for the implicit-cast cache.
+// Types are compared by their string representation rather than pointer
+// identity since equivalent types are frequently reconstructed by the
+// parser/resolver.
+type castKey struct {
+	target string
+	source string
+}
+
+const (
+	colorDim    = "\x1b[2m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorReset  = "\x1b[0m"
+)
+
+// IsImplicitCastable reports whether a value of type source can be used
+// where a value of type target is expected without an explicit cast.
+//
+// Results are memoized per Analyzer instance, keyed by the (target, source)
+// pair, since the same pair is re-checked many times across a large program
+// (e.g. every call argument against its parameter type).
+func (a *Analyzer) IsImplicitCastable(target, source types.Type) bool {
+	key := castKey{target: target.String(), source: source.String()}
+	if cached, ok := a.castCache[key]; ok {
+		a.Logger.Debugf(colorDim+"[analyzer] cache hit %s <- %s = %v"+colorReset, target, source, cached)
+		return cached
+	}
+
+	result := a.computeImplicitCastable(target, source)
+	a.castCache[key] = result
+	return result
+}
+
+func (a *Analyzer) computeImplicitCastable(target, source types.Type) bool {
+	if a.isTypeEqual(target, source) {
+		a.Logger.Debugf(colorGreen+"[analyzer] %s == %s: castable (identical)"+colorReset, target, source)
+		return true
+	}
+
+	if target.Kind() == types.KindPointer && source.Kind() == types.KindPointer {
+		result := a.IsImplicitCastable(target.(*types.Pointer).Elem, source.(*types.Pointer).Elem)
+		a.Logger.Debugf("[analyzer] %s <- %s: pointer element castable = %v", target, source, result)
+		return result
+	}
+
+	if target.Kind() == types.KindArray && source.Kind() == types.KindArray {
+		ta, sa := target.(*types.Array), source.(*types.Array)
+		if ta.Len != sa.Len {
+			a.Logger.Debugf(colorYellow+"[analyzer] %s <- %s: array length mismatch"+colorReset, target, source)
+			return false
+		}
+		return a.IsImplicitCastable(ta.Elem, sa.Elem)
+	}
+
+	if types.IsNumeric(target) && types.IsNumeric(source) {
+		// Widening numeric conversions (e.g. i32 -> i64, f32 -> f64) are
+		// implicit; narrowing ones require an explicit cast.
+		result := types.NumericRank(target) >= types.NumericRank(source)
+		a.Logger.Debugf("[analyzer] %s <- %s: numeric widening = %v", target, source, result)
+		return result
+	}
+
+	a.Logger.Debugf(colorYellow+"[analyzer] %s <- %s: not castable"+colorReset, target, source)
+	return false
+}
+
+// isTypeEqual reports whether two types are structurally identical. It is
+// also memoized implicitly by IsImplicitCastable's cache, since equal types
+// are always castable.
+func (a *Analyzer) isTypeEqual(t1, t2 types.Type) bool {
+	if t1.Kind() != t2.Kind() {
+		return false
+	}
+	switch t1.Kind() {
+	case types.KindPrimitive:
+		return t1.String() == t2.String()
+	case types.KindStruct:
+		return t1.(*types.Struct).Name == t2.(*types.Struct).Name
+	case types.KindPointer:
+		return a.isTypeEqual(t1.(*types.Pointer).Elem, t2.(*types.Pointer).Elem)
+	case types.KindArray:
+		a1, a2 := t1.(*types.Array), t2.(*types.Array)
+		return a1.Len == a2.Len && a.isTypeEqual(a1.Elem, a2.Elem)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/log"
+	"github.com/itsfuad/ferret-compiler/compiler/types"
+)
+
+func TestIsImplicitCastableWidening(t *testing.T) {
+	a := New()
+
+	if !a.IsImplicitCastable(types.I64, types.I32) {
+		t.Errorf("expected i32 -> i64 to be implicitly castable")
+	}
+	if a.IsImplicitCastable(types.I32, types.I64) {
+		t.Errorf("expected i64 -> i32 to require an explicit cast")
+	}
+}
+
+func TestIsImplicitCastableIdentity(t *testing.T) {
+	a := New()
+
+	if !a.IsImplicitCastable(types.Bool, types.Bool) {
+		t.Errorf("expected identical types to be castable")
+	}
+}
+
+func TestIsImplicitCastablePointers(t *testing.T) {
+	a := New()
+
+	p1 := &types.Pointer{Elem: types.I32}
+	p2 := &types.Pointer{Elem: types.I64}
+
+	if !a.IsImplicitCastable(p2, p1) {
+		t.Errorf("expected *i32 -> *i64 to be castable (element widens)")
+	}
+}
+
+func TestNewHasNoLoggerSoItTracesNothing(t *testing.T) {
+	a := New()
+	a.IsImplicitCastable(types.I64, types.I32)
+	// a.Logger is nil; IsImplicitCastable must not panic calling through it.
+}
+
+func TestNewWithOptionsTracesDecisionsToTheGivenLogger(t *testing.T) {
+	var buf bytes.Buffer
+	a := NewWithOptions(Options{Logger: log.New(log.LevelDebug, &buf)})
+
+	a.IsImplicitCastable(types.I64, types.I32)
+
+	if !strings.Contains(buf.String(), "[analyzer]") {
+		t.Errorf("expected a trace message on the configured logger, got %q", buf.String())
+	}
+}
+
+func TestConcurrentAnalyzersDoNotShareState(t *testing.T) {
+	const n = 8
+	done := make(chan *Analyzer, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			a := New()
+			a.IsImplicitCastable(types.I64, types.I32)
+			done <- a
+		}()
+	}
+
+	seen := make([]*Analyzer, 0, n)
+	for i := 0; i < n; i++ {
+		seen = append(seen, <-done)
+	}
+	for i, a := range seen {
+		for j, b := range seen {
+			if i != j && a == b {
+				t.Errorf("expected every New() to return an independent Analyzer, got the same instance twice")
+			}
+		}
+		if len(a.castCache) != 1 {
+			t.Errorf("expected each concurrently created Analyzer to have its own unshared cache, got %d entries", len(a.castCache))
+		}
+	}
+}
+
+func TestIsImplicitCastableCachesResult(t *testing.T) {
+	a := New()
+
+	a.IsImplicitCastable(types.I64, types.I32)
+	key := castKey{target: types.I64.String(), source: types.I32.String()}
+	if _, ok := a.castCache[key]; !ok {
+		t.Errorf("expected result to be memoized in castCache")
+	}
+}
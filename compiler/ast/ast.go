@@ -0,0 +1,145 @@
+// Package ast defines the minimal syntax tree nodes the backend currently
+// consumes directly. A proper parser-produced AST and an IR sitting between
+// it and codegen are expected to grow this package considerably.
+package ast
+
+// Param is a single function parameter.
+type Param struct {
+	Name string
+	Type string
+}
+
+// FunctionDecl is a top-level function definition.
+type FunctionDecl struct {
+	Name   string
+	Params []Param
+	Body   []Stmt
+}
+
+// ExternFuncDecl declares a function implemented outside the module — in
+// libc or another object linked in alongside it — following the platform
+// C ABI. It has no Body: codegen emits an `extern` symbol reference and a
+// plain `call` instead of a function label.
+type ExternFuncDecl struct {
+	Name       string
+	Params     []Param
+	ReturnType string
+}
+
+// Stmt is implemented by every statement node.
+type Stmt interface{ stmtNode() }
+
+// VarDecl declares and initializes a local variable.
+type VarDecl struct {
+	Name string
+	Init Expr
+}
+
+func (*VarDecl) stmtNode() {}
+
+// ReturnStmt returns Value (nil for a bare `return`) from the enclosing
+// function.
+type ReturnStmt struct {
+	Value Expr
+}
+
+func (*ReturnStmt) stmtNode() {}
+
+// ExprStmt evaluates X and discards the result.
+type ExprStmt struct {
+	X Expr
+}
+
+func (*ExprStmt) stmtNode() {}
+
+// IfStmt is a conditional with an optional else branch.
+type IfStmt struct {
+	Cond Expr
+	Then []Stmt
+	Else []Stmt
+}
+
+func (*IfStmt) stmtNode() {}
+
+// Expr is implemented by every expression node.
+type Expr interface{ exprNode() }
+
+// IntLiteral is an integer constant.
+type IntLiteral struct {
+	Value int64
+}
+
+func (*IntLiteral) exprNode() {}
+
+// FloatLiteral is a floating-point constant.
+type FloatLiteral struct {
+	Value float64
+}
+
+func (*FloatLiteral) exprNode() {}
+
+// Ident references a parameter or local variable by name.
+type Ident struct {
+	Name string
+}
+
+func (*Ident) exprNode() {}
+
+// FunctionCallExpr calls Callee with Args.
+type FunctionCallExpr struct {
+	Callee string
+	Args   []Expr
+}
+
+func (*FunctionCallExpr) exprNode() {}
+
+// BinaryOp identifies a binary operator.
+type BinaryOp int
+
+const (
+	OpAdd BinaryOp = iota
+	OpSub
+	OpMul
+	OpDiv // signed integer division; codegen traps on a zero divisor
+	OpEq
+	OpLt
+	OpLAnd // &&, short-circuiting
+	OpLOr  // ||, short-circuiting
+)
+
+// BinaryExpr applies Op to Left and Right.
+type BinaryExpr struct {
+	Op    BinaryOp
+	Left  Expr
+	Right Expr
+}
+
+func (*BinaryExpr) exprNode() {}
+
+// FloatOp identifies a binary operator over floating-point operands,
+// lowered to SSE2 instructions instead of their integer counterparts.
+type FloatOp int
+
+const (
+	FOpAdd FloatOp = iota
+	FOpSub
+	FOpLt
+)
+
+// FloatBinaryExpr applies a floating-point operator to two float-valued
+// operands.
+type FloatBinaryExpr struct {
+	Op    FloatOp
+	Left  Expr
+	Right Expr
+}
+
+func (*FloatBinaryExpr) exprNode() {}
+
+// CastExpr converts Value between the integer and floating-point domains.
+type CastExpr struct {
+	Value   Expr
+	ToFloat bool // true: int -> float, false: float -> int
+}
+
+func (*CastExpr) exprNode() {}
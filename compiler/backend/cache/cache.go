@@ -0,0 +1,75 @@
+// Package cache stores assembled object files keyed by a content hash of
+// the module (and whatever build options affect its codegen) that produced
+// them, so an unchanged module is relinked straight from disk instead of
+// being regenerated and reassembled on every build.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Cache is a directory of object files named after the key that produced
+// them.
+type Cache struct {
+	Dir string
+}
+
+// New returns a Cache rooted at dir, creating it if it doesn't exist.
+func New(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cache: creating %s: %w", dir, err)
+	}
+	return &Cache{Dir: dir}, nil
+}
+
+// Key hashes a module's source together with salt — anything else that
+// affects its generated code, such as optimization level or target triple
+// — so changing either invalidates the resulting cache entry.
+func Key(source []byte, salt string) string {
+	h := sha256.New()
+	h.Write(source)
+	h.Write([]byte{0}) // separator, so ("ab", "c") and ("a", "bc") don't collide
+	h.Write([]byte(salt))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Lookup returns the cached object file's path for key, if present.
+func (c *Cache) Lookup(key string) (string, bool) {
+	path := c.objectPath(key)
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// Store copies the object file at objPath into the cache under key and
+// returns its cached path.
+func (c *Cache) Store(key, objPath string) (string, error) {
+	dst := c.objectPath(key)
+
+	src, err := os.Open(objPath)
+	if err != nil {
+		return "", fmt.Errorf("cache: reading %s: %w", objPath, err)
+	}
+	defer src.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return "", fmt.Errorf("cache: writing %s: %w", dst, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, src); err != nil {
+		return "", fmt.Errorf("cache: writing %s: %w", dst, err)
+	}
+	return dst, nil
+}
+
+func (c *Cache) objectPath(key string) string {
+	return filepath.Join(c.Dir, key+".o")
+}
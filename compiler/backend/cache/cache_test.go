@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookupMissesThenHitsAfterStore(t *testing.T) {
+	c, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	key := Key([]byte("fn main() {}"), "linux,O0")
+	if _, ok := c.Lookup(key); ok {
+		t.Fatal("expected a miss before anything is stored")
+	}
+
+	objPath := filepath.Join(t.TempDir(), "mod.o")
+	if err := os.WriteFile(objPath, []byte("fake object"), 0o644); err != nil {
+		t.Fatalf("writing fake object: %v", err)
+	}
+
+	cached, err := c.Store(key, objPath)
+	if err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	got, ok := c.Lookup(key)
+	if !ok {
+		t.Fatal("expected a hit after Store")
+	}
+	if got != cached {
+		t.Errorf("Lookup returned %q, want %q", got, cached)
+	}
+}
+
+func TestKeyDistinguishesSourceAndSalt(t *testing.T) {
+	a := Key([]byte("same source"), "linux")
+	b := Key([]byte("same source"), "windows")
+	if a == b {
+		t.Error("expected different salts to produce different keys")
+	}
+
+	c := Key([]byte("different source"), "linux")
+	if a == c {
+		t.Error("expected different sources to produce different keys")
+	}
+}
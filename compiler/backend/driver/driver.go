@@ -0,0 +1,80 @@
+// Package driver shells out to an external assembler and linker to turn
+// generated assembly into a native executable, so callers don't have to
+// invoke nasm/ld by hand.
+package driver
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/itsfuad/ferret-compiler/compiler/target"
+)
+
+// Assemble runs nasm over the assembly at asmPath and produces an object
+// file next to it, in t's native object format. It returns the object
+// file's path.
+func Assemble(asmPath string, t target.Target) (string, error) {
+	objPath := replaceExt(asmPath, ".o")
+
+	cmd := exec.Command("nasm", "-f", t.ObjFormat(), "-o", objPath, asmPath)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("driver: assembling %s: %w", asmPath, err)
+	}
+	return objPath, nil
+}
+
+// LinkOptions controls how Link combines objects into an executable.
+type LinkOptions struct {
+	// LinkLibC additionally pulls in the platform C library, for programs
+	// with `extern fn` bindings into it.
+	LinkLibC bool
+
+	// PIC links a position-independent executable instead of one at a
+	// fixed load address. The generator already addresses every global
+	// RIP-relative (see x86.GeneratorOptions.PIC), so this only changes
+	// how the linker lays the binary out.
+	PIC bool
+}
+
+// Link invokes the platform linker to combine objPaths into a single
+// executable written to output.
+func Link(objPaths []string, output string, t target.Target, opts LinkOptions) error {
+	name, args := linkerCommand(objPaths, output, t, opts)
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("driver: linking %s: %w", output, err)
+	}
+	return nil
+}
+
+func linkerCommand(objPaths []string, output string, t target.Target, opts LinkOptions) (string, []string) {
+	var extra []string
+	if opts.LinkLibC {
+		extra = append(extra, t.LibCLinkArgs()...)
+	}
+
+	switch t.OS {
+	case target.Windows:
+		args := append([]string{"/out:" + output}, objPaths...)
+		return "lld-link", append(args, extra...)
+	case target.MacOS:
+		args := append([]string{"-o", output}, objPaths...)
+		return "ld64", append(args, extra...)
+	default:
+		args := []string{"-o", output}
+		if opts.PIC {
+			args = append(args, "-pie")
+		}
+		args = append(args, objPaths...)
+		return "ld", append(args, extra...)
+	}
+}
+
+func replaceExt(path, newExt string) string {
+	ext := filepath.Ext(path)
+	return path[:len(path)-len(ext)] + newExt
+}
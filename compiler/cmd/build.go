@@ -0,0 +1,444 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+	"github.com/itsfuad/ferret-compiler/compiler/backend/cache"
+	"github.com/itsfuad/ferret-compiler/compiler/backend/driver"
+	"github.com/itsfuad/ferret-compiler/compiler/codegen/x86"
+	"github.com/itsfuad/ferret-compiler/compiler/report"
+	"github.com/itsfuad/ferret-compiler/compiler/target"
+	"github.com/itsfuad/ferret-compiler/runtime/rt"
+)
+
+// buildOptions holds the parsed flags for `ferret build`.
+type buildOptions struct {
+	input      string
+	output     string
+	emitAsm    bool
+	optLevel   int
+	target     target.Target
+	pic        bool
+	saveTemps  bool
+	dumpStages map[string]bool
+}
+
+// knownDumpStages are the pipeline stages --dump accepts. tokens and ast
+// are accepted but currently produce nothing: there's no lexer/parser yet
+// (see the TODO in build), only the ir and asm stages the backend actually
+// runs.
+var knownDumpStages = map[string]bool{"tokens": true, "ast": true, "ir": true, "asm": true}
+
+// parseDumpStages splits a --dump value into the set of stages it names,
+// rejecting anything knownDumpStages doesn't recognize.
+func parseDumpStages(s string) (map[string]bool, error) {
+	stages := map[string]bool{}
+	if s == "" {
+		return stages, nil
+	}
+	for _, name := range strings.Split(s, ",") {
+		if !knownDumpStages[name] {
+			return nil, fmt.Errorf("--dump: unknown stage %q (want tokens, ast, ir or asm)", name)
+		}
+		stages[name] = true
+	}
+	return stages, nil
+}
+
+func runBuild(args []string) error {
+	fs := flag.NewFlagSet("build", flag.ContinueOnError)
+	output := fs.String("o", "", "output executable path")
+	emitAsm := fs.Bool("emit-asm", false, "write the generated x86-64 assembly next to the output")
+	// There's no project config file yet for a [profile] section to read
+	// this from, so the CLI flag is the only way to set it for now.
+	optLevel := fs.Int("O", 0, "optimization level (0, 1 or 2)")
+	targetTriple := fs.String("target", "", "target triple, e.g. x86_64-linux-gnu, x86_64-pc-windows-msvc, x86_64-apple-darwin")
+	pic := fs.Bool("fpic", false, "generate a position-independent executable")
+	saveTemps := fs.Bool("save-temps", false, "keep intermediate build artifacts (.asm, .o, .ir) in a build directory next to the output")
+	dump := fs.String("dump", "", "comma-separated pipeline stages to write to the build directory: tokens,ast,ir,asm")
+	diagnosticsFormat := fs.String("diagnostics-format", "text", "diagnostics output format: text, json, ndjson or sarif")
+	jsonFlag := fs.Bool("json", false, "shorthand for -diagnostics-format ndjson")
+	maxErrors := fs.Int("max-errors", 20, "stop showing individual errors after this many and print a summary instead (0 means no limit); only applies to -diagnostics-format text")
+	// There's no project config file yet for a [lint] werror setting to read
+	// this from, so the CLI flag is the only way to set it for now.
+	werror := fs.Bool("werror", false, "treat warnings as errors")
+	color := fs.String("color", "auto", "color diagnostics output: auto, always or never; only applies to -diagnostics-format text")
+	watchFlag := fs.Bool("watch", false, "rebuild whenever the input file changes, until interrupted")
+	quiet := fs.Bool("quiet", false, "suppress routine status messages (e.g. -watch's rebuild timing); errors still print")
+	verboseFlag := fs.Bool("verbose", false, "the opposite of -quiet; also settable via FERRET_LOG")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("usage: ferret build [-o output] [-emit-asm] [-O level] [-target triple] [-fpic] [-save-temps] [-dump stages] [-diagnostics-format text|json|ndjson|sarif] [-json] [-max-errors N] [-werror] [-color auto|always|never] [-watch] [-quiet] [-verbose] <file.fer>")
+	}
+	level, err := resolveVerbosity(*quiet, *verboseFlag)
+	if err != nil {
+		return err
+	}
+	resolvedFormat, err := resolveDiagnosticsFormat(*jsonFlag, *diagnosticsFormat)
+	if err != nil {
+		return err
+	}
+	diagnosticsFormat = &resolvedFormat
+	if *maxErrors < 0 {
+		return fmt.Errorf("ferret: -max-errors must be >= 0, got %d", *maxErrors)
+	}
+	colorMode := report.ColorMode(*color)
+	switch colorMode {
+	case report.ColorAuto, report.ColorAlways, report.ColorNever:
+	default:
+		return fmt.Errorf("ferret: -color must be auto, always or never, got %q", *color)
+	}
+
+	t, err := target.Parse(*targetTriple)
+	if err != nil {
+		return fmt.Errorf("ferret: %w", err)
+	}
+	if *optLevel < 0 || *optLevel > 2 {
+		return fmt.Errorf("ferret: -O must be 0, 1 or 2, got %d", *optLevel)
+	}
+	dumpStages, err := parseDumpStages(*dump)
+	if err != nil {
+		return fmt.Errorf("ferret: %w", err)
+	}
+
+	opts := buildOptions{
+		input:      fs.Arg(0),
+		output:     *output,
+		emitAsm:    *emitAsm,
+		optLevel:   *optLevel,
+		target:     t,
+		pic:        *pic,
+		saveTemps:  *saveTemps,
+		dumpStages: dumpStages,
+	}
+	if opts.output == "" {
+		opts.output = strings.TrimSuffix(filepath.Base(opts.input), filepath.Ext(opts.input))
+	}
+
+	if *watchFlag {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return watch(ctx, opts.input, func() error {
+			return buildAndDiagnose(opts, *diagnosticsFormat, *maxErrors, *werror, colorMode)
+		}, os.Stdout, level)
+	}
+
+	return buildAndDiagnose(opts, *diagnosticsFormat, *maxErrors, *werror, colorMode)
+}
+
+// buildAndDiagnose runs build and renders its outcome as diagnostics in the
+// requested format, the way runBuild reports a build to the terminal. It's
+// shared with runRun, which needs the identical build-then-report-diagnostics
+// behavior before deciding whether to run the result.
+func buildAndDiagnose(opts buildOptions, diagnosticsFormat string, maxErrors int, werror bool, colorMode report.ColorMode) error {
+	return diagnoseResult(build(opts), "build", diagnosticsFormat, maxErrors, werror, colorMode)
+}
+
+// resolveDiagnosticsFormat validates format and applies -json, the
+// shorthand for -diagnostics-format ndjson that build, check and run all
+// offer for CI systems that want one compact JSON object per line instead
+// of remembering the longer flag's exact spelling.
+func resolveDiagnosticsFormat(jsonFlag bool, format string) (string, error) {
+	if jsonFlag {
+		format = "ndjson"
+	}
+	switch format {
+	case "text", "json", "ndjson", "sarif":
+		return format, nil
+	default:
+		return "", fmt.Errorf("ferret: -diagnostics-format must be text, json, ndjson or sarif, got %q", format)
+	}
+}
+
+// diagnoseResult renders phaseErr (nil on success) as diagnostics in the
+// requested format, the way runBuild and runCheck report their outcome to
+// the terminal. phase is the report source: "build" or "check".
+func diagnoseResult(phaseErr error, phase string, diagnosticsFormat string, maxErrors int, werror bool, colorMode report.ColorMode) error {
+	var reports []report.Report
+	if phaseErr != nil {
+		reports = []report.Report{report.FromError(phase, report.CodeBuildFailed, phaseErr)}
+	}
+	diagnostics := report.Reports(reports).Dedup().Sort()
+	if werror {
+		diagnostics = diagnostics.PromoteWarnings()
+	}
+	hasErrors := diagnostics.HasErrors()
+	// Diagnostics go to stderr on failure (formattedDiagnosticsError, below)
+	// and stdout on success, so -color=auto's terminal detection checks
+	// whichever stream they're actually about to land on.
+	stream := os.Stdout
+	if hasErrors {
+		stream = os.Stderr
+	}
+
+	var formatted string
+	var err error
+	switch diagnosticsFormat {
+	case "json":
+		formatted, err = report.FormatJSON(diagnostics)
+	case "ndjson":
+		formatted, err = report.FormatNDJSON(diagnostics)
+	case "sarif":
+		formatted, err = report.FormatSARIF(diagnostics)
+	default:
+		formatted, err = formatTextDiagnostics(diagnostics, maxErrors, report.ResolveColor(colorMode, stream))
+	}
+	if err != nil {
+		return err
+	}
+	if !hasErrors {
+		// Text and ndjson have nothing to print on a clean build (an empty
+		// Reports is zero lines either way); json/sarif always print their
+		// (possibly empty) array/log so a caller parsing stdout doesn't
+		// need to special-case success.
+		if formatted != "" {
+			fmt.Println(formatted)
+		}
+		return nil
+	}
+	// main.go prints whatever Execute returns via fmt.Fprintln(os.Stderr,
+	// ...), which is why wrapping the formatted text in an error is enough
+	// to switch formats without build and main needing to coordinate on
+	// anything beyond the error value.
+	return formattedDiagnosticsError(formatted)
+}
+
+// formatTextDiagnostics renders reports the way a terminal build error has
+// always looked: via report.Reports.DisplayLimited, source snippet, summary
+// footer and all.
+func formatTextDiagnostics(reports []report.Report, maxErrors int, color bool) (string, error) {
+	var buf bytes.Buffer
+	if err := report.Reports(reports).DisplayLimited(&buf, maxErrors, color); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// formattedDiagnosticsError carries an already-formatted diagnostics
+// payload (text, JSON or SARIF) as its message, for --diagnostics-format.
+type formattedDiagnosticsError string
+
+func (e formattedDiagnosticsError) Error() string {
+	return string(e)
+}
+
+// build runs the backend pipeline: generate assembly for the module, then
+// assemble and link it into opts.output via the external nasm/ld driver.
+// The assembly is written to a temp file unless -emit-asm asks for it to be
+// kept next to the output. The module's object file is cached by content
+// hash (see objectCacheDir), so rebuilding with an unchanged source and
+// flags skips straight to linking.
+//
+// This already produces a linked, runnable executable — there's no
+// commented-out backend path left to finish here. What's missing is
+// upstream of this function: a frontend. fns and externs below are always
+// empty because there's no lexer, parser, or typecheck phase yet to
+// populate them from opts.input (see the TODOs just below); `ferret build`
+// exercises the whole codegen-through-link pipeline today with whichever
+// declarations it's handed, which for now is none.
+func build(opts buildOptions) error {
+	moduleName := strings.TrimSuffix(filepath.Base(opts.input), filepath.Ext(opts.input))
+
+	source, err := os.ReadFile(opts.input)
+	if err != nil {
+		return fmt.Errorf("ferret: reading %s: %w", opts.input, err)
+	}
+
+	objCache, err := cache.New(objectCacheDir())
+	if err != nil {
+		return err
+	}
+
+	// TODO: parse opts.input into function and extern declarations once the
+	// frontend lands; until then the backend pipeline is exercised with
+	// none of either. tokens/ast dumps have nothing to write yet for the
+	// same reason.
+	//
+	// TODO: there's also no import graph yet — opts.input is the only
+	// module a build ever sees. Once imports exist, collect the graph
+	// first and parse independent modules concurrently on a worker pool
+	// (lexing/parsing is pure per file) before registering them, rather
+	// than parsing the graph one module at a time. Size the pool from
+	// runtime.GOMAXPROCS(0) the way the rest of the toolchain would; per-
+	// module resolution/typechecking can run the same way once it no
+	// longer mutates shared state directly. Reports from different
+	// modules still need to come back in a deterministic order (e.g. by
+	// the graph's topological position, not completion order) so -werror
+	// and diagnostics output don't flap between runs just because two
+	// modules happened to finish in a different order.
+	//
+	// TODO: there's also no fer.work workspace file, so a build only ever
+	// sees the single project opts.input lives in — an intra-workspace
+	// import naming a sibling project has nowhere to resolve to, and
+	// `ferret build`/`check` have no notion of "every member" to iterate
+	// over. Once a workspace file exists, running a command across its
+	// members is the same worker-pool-over-independent-modules shape
+	// described above, just with a member's own import graph as the unit
+	// of work instead of a single file; a shared lockfile governing
+	// external versions belongs with the dependency manager this backlog's
+	// other TODOs are also waiting on, not duplicated per member.
+	var fns []*ast.FunctionDecl
+	var externs []*ast.ExternFuncDecl
+
+	if err := x86.ValidateEntryPoint(fns); err != nil {
+		return fmt.Errorf("ferret: %w", err)
+	}
+
+	var artifactDir string
+	if opts.saveTemps || len(opts.dumpStages) > 0 {
+		artifactDir = buildDirFor(opts.output)
+		if err := os.MkdirAll(artifactDir, 0o755); err != nil {
+			return fmt.Errorf("ferret: creating build directory %s: %w", artifactDir, err)
+		}
+	}
+
+	cacheKey := cache.Key(source, cacheSalt(opts))
+	objPath, hit := objCache.Lookup(cacheKey)
+	if !hit {
+		gen := x86.NewGeneratorWithOptions(x86.GeneratorOptions{OptLevel: opts.optLevel, Target: opts.target, PIC: opts.pic, DumpIR: opts.dumpStages["ir"], EntryModule: true})
+		asm := gen.Generate(moduleName, fns, externs)
+
+		if opts.dumpStages["ir"] {
+			irPath := filepath.Join(artifactDir, moduleName+".ir")
+			if err := os.WriteFile(irPath, []byte(gen.IRDump()), 0o644); err != nil {
+				return fmt.Errorf("ferret: writing %s: %w", irPath, err)
+			}
+		}
+
+		asmPath := asmOutputPath(opts.output)
+		if err := os.WriteFile(asmPath, []byte(asm), 0o644); err != nil {
+			return fmt.Errorf("ferret: writing %s: %w", asmPath, err)
+		}
+		if !opts.emitAsm {
+			defer os.Remove(asmPath)
+		}
+		if opts.saveTemps || opts.dumpStages["asm"] {
+			dumpPath := filepath.Join(artifactDir, moduleName+".asm")
+			if err := copyFile(asmPath, dumpPath); err != nil {
+				return err
+			}
+		}
+
+		assembled, err := driver.Assemble(asmPath, opts.target)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(assembled)
+		if opts.saveTemps {
+			if err := copyFile(assembled, filepath.Join(artifactDir, moduleName+".o")); err != nil {
+				return err
+			}
+		}
+
+		objPath, err = objCache.Store(cacheKey, assembled)
+		if err != nil {
+			return err
+		}
+	}
+
+	runtimeObjPath, err := assembleRuntime(opts.target)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(runtimeObjPath)
+	if opts.saveTemps {
+		if err := copyFile(runtimeObjPath, filepath.Join(artifactDir, "rt.o")); err != nil {
+			return err
+		}
+	}
+
+	linkOpts := driver.LinkOptions{LinkLibC: len(externs) > 0, PIC: opts.pic}
+	return driver.Link([]string{objPath, runtimeObjPath}, opts.output, opts.target, linkOpts)
+}
+
+// objectCacheDir is where build caches per-module object files, keyed by
+// content hash. It lives under the user's cache directory so it survives
+// across invocations and projects the way Go's own build cache does.
+//
+// This is today's entire on-disk incremental cache: object code only,
+// keyed on source + codegen-affecting flags (see cacheSalt). Caching
+// earlier stages — an AST fingerprint, exported symbol signatures,
+// typecheck results, so `ferret check` and an LSP session could skip
+// re-analyzing an unchanged module too — has to wait for those stages to
+// exist; there's no AST or typecheck phase yet for them to key on.
+func objectCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "ferret-build-cache")
+}
+
+// cacheSalt folds every build option that affects generated code into the
+// cache key, alongside the module's source, so changing a flag invalidates
+// the cached object the same way editing the source would.
+func cacheSalt(opts buildOptions) string {
+	return fmt.Sprintf("target=%d;O=%d;pic=%v", opts.target.OS, opts.optLevel, opts.pic)
+}
+
+// assembleRuntime writes the embedded runtime source (compiler/rt_alloc,
+// rt_panic, rt_print_int, ...) to a temp file and assembles it for t, so
+// every program links against it without the user managing a copy of it.
+func assembleRuntime(t target.Target) (string, error) {
+	f, err := os.CreateTemp("", "ferret-rt-*.asm")
+	if err != nil {
+		return "", fmt.Errorf("ferret: creating runtime temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(rt.Source); err != nil {
+		f.Close()
+		return "", fmt.Errorf("ferret: writing runtime source: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("ferret: writing runtime source: %w", err)
+	}
+
+	return driver.Assemble(f.Name(), t)
+}
+
+// buildDirFor is where --save-temps and --dump write intermediate pipeline
+// artifacts, next to the build output rather than under a temp directory,
+// so they're easy to find after the build finishes.
+func buildDirFor(output string) string {
+	return filepath.Join(filepath.Dir(output), filepath.Base(output)+".ferret-build")
+}
+
+// copyFile copies the file at src to dst, overwriting dst if it exists.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("ferret: reading %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("ferret: writing %s: %w", dst, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("ferret: writing %s: %w", dst, err)
+	}
+	return nil
+}
+
+// asmOutputPath derives the path for the emitted assembly file from the
+// build output path: basename.asm, next to the executable.
+func asmOutputPath(output string) string {
+	ext := filepath.Ext(output)
+	base := strings.TrimSuffix(output, ext)
+	return base + ".asm"
+}
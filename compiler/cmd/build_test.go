@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/target"
+)
+
+func TestAsmOutputPath(t *testing.T) {
+	cases := map[string]string{
+		"prog":     "prog.asm",
+		"prog.out": "prog.asm",
+		"a/b/prog": "a/b/prog.asm",
+	}
+	for output, want := range cases {
+		if got := asmOutputPath(output); got != want {
+			t.Errorf("asmOutputPath(%q) = %q, want %q", output, got, want)
+		}
+	}
+}
+
+func TestRunBuildRejectsOutOfRangeOptLevel(t *testing.T) {
+	err := runBuild([]string{"-O", "3", "prog.fer"})
+	if err == nil || !strings.Contains(err.Error(), "-O must be 0, 1 or 2") {
+		t.Errorf("expected an out-of-range -O to be rejected, got %v", err)
+	}
+}
+
+func TestParseDumpStages(t *testing.T) {
+	stages, err := parseDumpStages("ir,asm")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !stages["ir"] || !stages["asm"] || len(stages) != 2 {
+		t.Errorf("expected {ir, asm}, got %v", stages)
+	}
+
+	if _, err := parseDumpStages("bogus"); err == nil {
+		t.Errorf("expected an unknown stage to be rejected")
+	}
+
+	if stages, err := parseDumpStages(""); err != nil || len(stages) != 0 {
+		t.Errorf("expected an empty --dump to produce no stages, got %v, %v", stages, err)
+	}
+}
+
+func TestBuildRejectsModuleWithoutMain(t *testing.T) {
+	dir := t.TempDir()
+	input := dir + "/prog.fer"
+	if err := os.WriteFile(input, []byte(""), 0o644); err != nil {
+		t.Fatalf("writing fixture source: %v", err)
+	}
+
+	err := build(buildOptions{input: input, output: dir + "/prog", target: target.Default})
+	if err == nil || !strings.Contains(err.Error(), "no fn main()") {
+		t.Errorf("expected a missing-main error, got %v", err)
+	}
+}
+
+func TestRunBuildRejectsUnknownDiagnosticsFormat(t *testing.T) {
+	err := runBuild([]string{"-diagnostics-format", "xml", "prog.fer"})
+	if err == nil || !strings.Contains(err.Error(), "-diagnostics-format must be text, json, ndjson or sarif") {
+		t.Errorf("expected an unknown -diagnostics-format to be rejected, got %v", err)
+	}
+}
+
+func TestRunBuildJSONDiagnosticsFormatsFailureAsJSON(t *testing.T) {
+	err := runBuild([]string{"-diagnostics-format", "json", "does-not-exist.fer"})
+	if err == nil {
+		t.Fatal("expected an error for a missing input file")
+	}
+	if !strings.Contains(err.Error(), `"phase": "build"`) || !strings.Contains(err.Error(), `"severity": "error"`) {
+		t.Errorf("expected the error to be a JSON diagnostics array, got %v", err)
+	}
+}
+
+func TestRunBuildJSONFlagFormatsFailureAsNDJSON(t *testing.T) {
+	err := runBuild([]string{"-json", "does-not-exist.fer"})
+	if err == nil {
+		t.Fatal("expected an error for a missing input file")
+	}
+	if !strings.Contains(err.Error(), `"phase":"build"`) || strings.Contains(err.Error(), "\n") {
+		t.Errorf("expected a single-line NDJSON object, got %v", err)
+	}
+}
+
+func TestRunBuildSARIFDiagnosticsFormatsFailureAsSARIF(t *testing.T) {
+	err := runBuild([]string{"-diagnostics-format", "sarif", "does-not-exist.fer"})
+	if err == nil {
+		t.Fatal("expected an error for a missing input file")
+	}
+	if !strings.Contains(err.Error(), `"version": "2.1.0"`) || !strings.Contains(err.Error(), `"ruleId": "build"`) {
+		t.Errorf("expected the error to be a SARIF log, got %v", err)
+	}
+}
+
+func TestRunBuildRejectsUnknownColorMode(t *testing.T) {
+	err := runBuild([]string{"-color", "rainbow", "prog.fer"})
+	if err == nil || !strings.Contains(err.Error(), "-color must be auto, always or never") {
+		t.Errorf("expected an unknown -color to be rejected, got %v", err)
+	}
+}
+
+func TestRunBuildRejectsNegativeMaxErrors(t *testing.T) {
+	err := runBuild([]string{"-max-errors", "-1", "prog.fer"})
+	if err == nil || !strings.Contains(err.Error(), "-max-errors must be >= 0") {
+		t.Errorf("expected a negative -max-errors to be rejected, got %v", err)
+	}
+}
+
+func TestBuildDirFor(t *testing.T) {
+	if got, want := buildDirFor("a/b/prog"), "a/b/prog.ferret-build"; got != want {
+		t.Errorf("buildDirFor(%q) = %q, want %q", "a/b/prog", got, want)
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+	"github.com/itsfuad/ferret-compiler/compiler/codegen/x86"
+	"github.com/itsfuad/ferret-compiler/compiler/report"
+)
+
+// runCheck implements `ferret check`: run everything build does up to but
+// not including codegen, and report diagnostics without ever invoking the
+// assembler or linker. It's meant for CI and editor integrations that only
+// want to know whether a module is valid, faster than a full build and
+// without needing nasm/ld on the machine running it.
+func runCheck(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	diagnosticsFormat := fs.String("diagnostics-format", "text", "diagnostics output format: text, json, ndjson or sarif")
+	jsonFlag := fs.Bool("json", false, "shorthand for -diagnostics-format ndjson")
+	maxErrors := fs.Int("max-errors", 20, "stop showing individual errors after this many and print a summary instead (0 means no limit); only applies to -diagnostics-format text")
+	werror := fs.Bool("werror", false, "treat warnings as errors")
+	color := fs.String("color", "auto", "color diagnostics output: auto, always or never; only applies to -diagnostics-format text")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("usage: ferret check [-diagnostics-format text|json|ndjson|sarif] [-json] [-max-errors N] [-werror] [-color auto|always|never] <file.fer>")
+	}
+	resolvedFormat, err := resolveDiagnosticsFormat(*jsonFlag, *diagnosticsFormat)
+	if err != nil {
+		return err
+	}
+	if *maxErrors < 0 {
+		return fmt.Errorf("ferret: -max-errors must be >= 0, got %d", *maxErrors)
+	}
+	colorMode := report.ColorMode(*color)
+	switch colorMode {
+	case report.ColorAuto, report.ColorAlways, report.ColorNever:
+	default:
+		return fmt.Errorf("ferret: -color must be auto, always or never, got %q", *color)
+	}
+
+	return diagnoseResult(check(fs.Arg(0)), "check", resolvedFormat, *maxErrors, *werror, colorMode)
+}
+
+// check runs the analysis-only prefix of build: read the source and
+// validate its declarations, without generating, assembling or linking
+// anything.
+//
+// Today that prefix is just ValidateEntryPoint, same as build — there's no
+// collect/resolve/typecheck pass to run either of them through yet (see
+// build's TODOs), so fns is always empty here too.
+func check(input string) error {
+	if _, err := os.ReadFile(input); err != nil {
+		return fmt.Errorf("ferret: reading %s: %w", input, err)
+	}
+
+	var fns []*ast.FunctionDecl
+	if err := x86.ValidateEntryPoint(fns); err != nil {
+		return fmt.Errorf("ferret: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCheckRejectsModuleWithoutMain(t *testing.T) {
+	dir := t.TempDir()
+	input := dir + "/prog.fer"
+	if err := os.WriteFile(input, []byte(""), 0o644); err != nil {
+		t.Fatalf("writing fixture source: %v", err)
+	}
+
+	err := check(input)
+	if err == nil || !strings.Contains(err.Error(), "no fn main()") {
+		t.Errorf("expected a missing-main error, got %v", err)
+	}
+}
+
+func TestCheckRejectsAMissingFile(t *testing.T) {
+	err := check("does-not-exist.fer")
+	if err == nil || !strings.Contains(err.Error(), "reading does-not-exist.fer") {
+		t.Errorf("expected a missing-file error, got %v", err)
+	}
+}
+
+func TestRunCheckRejectsUnknownDiagnosticsFormat(t *testing.T) {
+	err := runCheck([]string{"-diagnostics-format", "xml", "prog.fer"})
+	if err == nil || !strings.Contains(err.Error(), "-diagnostics-format must be text, json, ndjson or sarif") {
+		t.Errorf("expected an unknown -diagnostics-format to be rejected, got %v", err)
+	}
+}
+
+func TestRunCheckJSONDiagnosticsFormatsFailureAsJSON(t *testing.T) {
+	err := runCheck([]string{"-diagnostics-format", "json", "does-not-exist.fer"})
+	if err == nil {
+		t.Fatal("expected an error for a missing input file")
+	}
+	if !strings.Contains(err.Error(), `"phase": "check"`) || !strings.Contains(err.Error(), `"severity": "error"`) {
+		t.Errorf("expected the error to be a JSON diagnostics array, got %v", err)
+	}
+}
+
+func TestRunCheckRejectsExtraArgs(t *testing.T) {
+	err := runCheck([]string{"a", "b"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret check") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+// Package cmd implements the ferret command-line driver: argument parsing
+// and dispatch to the individual subcommands (build, run, ...).
+package cmd
+
+import (
+	"os"
+)
+
+// Execute parses os.Args and runs the requested subcommand. It is the sole
+// entry point called from main.
+func Execute() error {
+	args := os.Args[1:]
+	if len(args) == 0 {
+		return usageErrorf("usage: ferret <command> [arguments]")
+	}
+
+	return dispatch(args)
+}
+
+// dispatch runs the subcommand named by args[0], recovering a panic into an
+// internalErrorf instead of letting it reach main as a raw Go stack trace.
+// The backend has several "unhandled %T" panics (ir/lower.go, the x86
+// backend) for IR shapes that should be unreachable once there's a real
+// frontend validating programs before they get this far — today, with no
+// frontend, fns is always empty and none of them actually fire, but this is
+// where they'll land if that changes before the frontend does.
+func dispatch(args []string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = internalErrorf("ferret: internal error: %v", r)
+		}
+	}()
+
+	switch args[0] {
+	case "build":
+		return runBuild(args[1:])
+	case "run":
+		return runRun(args[1:])
+	case "test":
+		return runTest(args[1:])
+	case "check":
+		return runCheck(args[1:])
+	case "vet":
+		return runVet(args[1:])
+	case "init":
+		return runInit(args[1:])
+	case "graph":
+		return runGraph(args[1:])
+	case "update":
+		return runUpdate(args[1:])
+	case "vendor":
+		return runVendor(args[1:])
+	case "verify":
+		return runVerify(args[1:])
+	case "outdated":
+		return runOutdated(args[1:])
+	case "publish":
+		return runPublish(args[1:])
+	case "get":
+		return runGet(args[1:])
+	case "explain":
+		return runExplain(args[1:])
+	case "version":
+		return runVersion(args[1:])
+	case "lsp":
+		return runLSP(args[1:])
+	default:
+		return usageErrorf("ferret: unknown command %q", args[0])
+	}
+}
+
+// ExitCode reports the process exit status Execute's error asks for, for
+// main to use instead of always exiting 1. Most errors (a bad flag, a
+// build failure) don't implement this and get false, 0; `ferret run`
+// returns one so a program's own exit code survives running it through
+// ferret rather than always reading back as 1.
+func ExitCode(err error) (int, bool) {
+	ec, ok := err.(interface{ ExitCode() int })
+	if !ok {
+		return 0, false
+	}
+	return ec.ExitCode(), true
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import "fmt"
+
+// Exit codes ferret's subcommands return, roughly following the sysexits(3)
+// convention of reserving distinct ranges for distinct failure categories
+// rather than collapsing everything to 1. main.go falls back to 1 for any
+// error that doesn't implement ExitCode (see cmd.ExitCode), so these only
+// need defining where a command wants to be more specific than that
+// default.
+const (
+	// exitUsage is a bad flag or argument count — the command never got far
+	// enough to attempt the work it was asked to do. See usageErrorf.
+	exitUsage = 2
+
+	// exitDiagnostics is diagnoseResult's existing behavior for a build,
+	// check or vet that ran to completion but found errors: main's default
+	// (ExitCode returns false, 0, os.Exit falls back to 1) already matches
+	// this value, so nothing needs to change there — it's named here so the
+	// taxonomy has an entry for it instead of leaving it implicit.
+	exitDiagnostics = 1
+
+	// exitInternal is a panic recovered from a subcommand (see
+	// Execute's recover in cmd.go): a compiler invariant was violated
+	// (ir/lower.go and the x86 backend's "unhandled" panics are the usual
+	// source) rather than the input program being invalid. sysexits has no
+	// single best fit for this; 70 (EX_SOFTWARE) is the closest.
+	exitInternal = 70
+
+	// exitDependency is reserved for the dependency-resolution errors a real
+	// package manager would raise (version conflicts, an unreachable
+	// registry, a checksum mismatch) — see the honest-stub commands in
+	// graph.go, update.go, vendor.go, verify.go, outdated.go and
+	// publish.go. None of them return this yet, because none of them do
+	// dependency resolution yet; it's defined now so that work doesn't also
+	// have to invent its exit code later.
+	exitDependency = 69
+)
+
+// usageErrorf formats a "usage: ferret ..." message the way every
+// subcommand's flag-count/flag-value validation already does, but tags it
+// with exitUsage so main exits 2 instead of the generic 1 — distinguishing
+// "you called this wrong" from "this ran and failed".
+func usageErrorf(format string, args ...any) error {
+	return taggedExitError{err: fmt.Errorf(format, args...), code: exitUsage}
+}
+
+// internalErrorf is exitInternal's constructor, for Execute's recovered
+// panics; see exitInternal.
+func internalErrorf(format string, args ...any) error {
+	return taggedExitError{err: fmt.Errorf(format, args...), code: exitInternal}
+}
+
+// taggedExitError pairs an error with the exit code it should produce. It's
+// distinct from run.go's exitCodeError (which only ever carries a child
+// process's raw exit status with no message of its own) because this one
+// needs both a real error message and an arbitrary code.
+type taggedExitError struct {
+	err  error
+	code int
+}
+
+func (e taggedExitError) Error() string { return e.err.Error() }
+func (e taggedExitError) Unwrap() error { return e.err }
+func (e taggedExitError) ExitCode() int { return e.code }
@@ -0,0 +1,37 @@
+package cmd
+
+import "testing"
+
+func TestUsageErrorfSetsExitUsage(t *testing.T) {
+	err := usageErrorf("usage: ferret frob <file>")
+	code, ok := ExitCode(err)
+	if !ok || code != exitUsage {
+		t.Errorf("got (%d, %v), want (%d, true)", code, ok, exitUsage)
+	}
+	if err.Error() != "usage: ferret frob <file>" {
+		t.Errorf("unexpected message: %q", err.Error())
+	}
+}
+
+func TestInternalErrorfSetsExitInternal(t *testing.T) {
+	err := internalErrorf("ferret: internal error: %v", "boom")
+	code, ok := ExitCode(err)
+	if !ok || code != exitInternal {
+		t.Errorf("got (%d, %v), want (%d, true)", code, ok, exitInternal)
+	}
+}
+
+func TestDispatchRecoversAPanicAsAnInternalError(t *testing.T) {
+	// dispatch indexes args[0] with no length check of its own (Execute
+	// checks that before calling it) — an empty slice panics, which is as
+	// good a stand-in as any for the "unhandled %T" panics the backend can
+	// still raise on a shape its frontend-less callers never produce today.
+	err := dispatch([]string{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	code, ok := ExitCode(err)
+	if !ok || code != exitInternal {
+		t.Errorf("got (%d, %v), want (%d, true)", code, ok, exitInternal)
+	}
+}
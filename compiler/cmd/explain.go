@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/itsfuad/ferret-compiler/compiler/report"
+)
+
+// runExplain implements `ferret explain <code>`: printing the extended
+// description registered for a diagnostic code, beyond the one-line
+// message a Report carries when it's first reported.
+func runExplain(args []string) error {
+	if len(args) != 1 {
+		return usageErrorf("usage: ferret explain <code>")
+	}
+
+	code := report.Code(args[0])
+	explanation, ok := report.Explain(code)
+	if !ok {
+		return fmt.Errorf("ferret: no explanation registered for %s", code)
+	}
+
+	fmt.Printf("%s: %s\n\n%s\n\nExample:\n  %s\n", explanation.Code, explanation.Summary, explanation.Details, explanation.Example)
+	return nil
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunExplainKnownCode(t *testing.T) {
+	if err := runExplain([]string{"FER0001"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRunExplainUnknownCode(t *testing.T) {
+	err := runExplain([]string{"FER9999"})
+	if err == nil || !strings.Contains(err.Error(), "no explanation registered") {
+		t.Errorf("expected an unknown code to be rejected, got %v", err)
+	}
+}
+
+func TestRunExplainRequiresExactlyOneArg(t *testing.T) {
+	if err := runExplain(nil); err == nil {
+		t.Error("expected an error with no code given")
+	}
+	if err := runExplain([]string{"FER0001", "extra"}); err == nil {
+		t.Error("expected an error with more than one argument")
+	}
+}
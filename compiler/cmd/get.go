@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/itsfuad/ferret-compiler/compiler/semver"
+)
+
+// remoteModuleHosts are the hosts with a recognized release API —
+// downloadBackend releaseAPI, below — that a remote import can name.
+// github.com is listed for symmetry even though, like the others, nothing
+// downloads from it yet — see runGet.
+var remoteModuleHosts = []string{"github.com", "gitlab.com", "bitbucket.org"}
+
+// downloadBackend is which of the two ways ferret get would fetch a
+// module: a host's release API (a tagged tarball/zipball in one request,
+// see runGet's note on that), or a shallow git clone for a host with no
+// such API.
+type downloadBackend int
+
+const (
+	backendReleaseAPI downloadBackend = iota
+	backendGitClone
+)
+
+func (b downloadBackend) String() string {
+	if b == backendGitClone {
+		return "a shallow git clone"
+	}
+	return "its release API"
+}
+
+// pinKind distinguishes the three ways a module reference can pin which
+// revision to fetch.
+type pinKind int
+
+const (
+	pinVersion pinKind = iota
+	pinBranch
+	pinCommit
+)
+
+// modulePin is the parsed form of a reference's @-suffix: a semver
+// constraint by default, or an explicit branch:name or commit:sha. This
+// mirrors the syntax fer.ret dependencies would presumably use once
+// fer.ret itself exists — see runGet.
+type modulePin struct {
+	kind       pinKind
+	constraint semver.Constraint
+	name       string // branch name or commit SHA, for pinBranch/pinCommit
+}
+
+func (p modulePin) String() string {
+	switch p.kind {
+	case pinBranch:
+		return "branch:" + p.name
+	case pinCommit:
+		return "commit:" + p.name
+	default:
+		if text := p.constraint.String(); text != "" {
+			return text
+		}
+		return "latest"
+	}
+}
+
+// remoteModuleRef is a parsed `ferret get` argument.
+type remoteModuleRef struct {
+	host    string
+	path    string
+	pin     modulePin
+	backend downloadBackend
+}
+
+// runGet implements `ferret get`: resolve a remote module reference like
+// gitlab.com/user/repo@v1 or github.com/user/repo@branch:main to a
+// concrete revision and fetch its source into the dependency cache, the
+// way `go get` does for a module path.
+//
+// There's no DependencyManager, registry client, or download backend
+// anywhere in this repo — no package manager exists yet at all, for any
+// host or pin kind. Recognizing which hosts and pin syntaxes a reference
+// is even allowed to name is the one piece of this that doesn't need one,
+// so that much is real; it validates its own argument for real and then
+// explains the rest, rather than pretending to fetch something. In
+// particular there's no lockfile to record a branch or tag's resolved
+// commit SHA into once a download backend exists — see vendor.go's note
+// on the same gap from the checksum side.
+//
+// When a download backend does exist, it should cache what it fetches the
+// same way objectCacheDir already does for compiled objects: under
+// os.UserCacheDir, not a per-project .ferret directory, so two projects
+// needing the same dependency version only ever download it once. A
+// per-project directory would then layer on top as an overlay (a vendor/
+// directory is exactly that overlay, pre-copied out — see vendor.go) for
+// builds that want to pin exactly what they saw without touching the
+// shared cache.
+//
+// -offline is accepted and validated for real, for the CI and air-gapped
+// setups that will want it once a download backend exists, but it has
+// nothing to change yet: with no network access anywhere in this command
+// today, every ferret get already fails the same way -offline would ask
+// it to on a cache miss — just with a generic "no download backend"
+// message rather than the package-by-package "would need network for X"
+// listing -offline promises. That listing needs a resolver computing what
+// a fetch would have needed, which doesn't exist until the rest of this
+// does.
+//
+// FERRET_PROXY, if set, is validated as a real URL for the same reason:
+// there's no registry client yet to query it before falling back to a
+// module's origin host the way GOPROXY does for go get, but the env var's
+// shape is worth getting right now rather than leaving for whoever adds
+// one.
+//
+// When a download backend does exist, it should fetch a pin's tagged
+// release archive (tarball/zipball) in one request and extract it, rather
+// than walking the tree and pulling down one raw file per source path:
+// one request is faster than N, and a single archive is what the
+// checksum vendor.go is waiting on (see its note) would actually hash —
+// hashing a directory of independently-fetched files canonically is a
+// much harder problem than hashing one archive.
+//
+// A host outside remoteModuleHosts isn't rejected outright: it falls back
+// to the shallow-git-clone backend (downloadBackend, above) instead of
+// the release-API one, so an arbitrary self-hosted git server can still
+// host a module — just without the one-request archive download and the
+// arbitrary-commit pins that require a release API (or a full, non-
+// shallow clone) to resolve.
+func runGet(args []string) error {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	offline := fs.Bool("offline", false, "fail instead of making network requests; resolve purely from cache and lockfile")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("usage: ferret get [-offline] <host>/<user>/<repo>[@version|@branch:name|@commit:sha]")
+	}
+	_ = offline
+
+	proxy, err := resolveProxy()
+	if err != nil {
+		return err
+	}
+
+	ref, err := parseModuleRef(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	via := "its origin host"
+	if proxy != "" {
+		via = proxy + " (FERRET_PROXY), falling back to its origin host"
+	}
+
+	return taggedExitError{
+		code: exitDependency,
+		err: fmt.Errorf("ferret: get: %s/%s is a recognized module reference pinned to %s, and would be "+
+			"fetched via %s, queried via %s, but there's no registry client or download backend to fetch "+
+			"it with yet; this repo has no package manager substrate at all",
+			ref.host, ref.path, ref.pin, ref.backend, via),
+	}
+}
+
+// resolveProxy reads FERRET_PROXY and checks it's a well-formed absolute
+// URL, returning "" if the env var is unset.
+func resolveProxy() (string, error) {
+	raw := os.Getenv("FERRET_PROXY")
+	if raw == "" {
+		return "", nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() {
+		return "", fmt.Errorf("ferret: get: FERRET_PROXY=%q is not a valid absolute URL", raw)
+	}
+	return raw, nil
+}
+
+// parseModuleRef parses ref as <host>/<user>/<repo>[@pin]. host selects a
+// downloadBackend automatically: one of remoteModuleHosts gets the
+// release API, any other host that's at least shaped like a domain falls
+// back to a shallow git clone, the way an arbitrary self-hosted git
+// server would have to be fetched with no release API of its own to ask.
+// pin, if present, must be a syntactically valid semver constraint,
+// branch:name, or commit:sha — and a commit pin is rejected outright for
+// the git-clone backend, since `git clone --depth 1 --branch` takes a ref
+// name, not an arbitrary commit; reaching a specific commit needs a full
+// (non-shallow) clone, which isn't what this pin syntax asks for.
+func parseModuleRef(ref string) (remoteModuleRef, error) {
+	path := ref
+	pinText := ""
+	if i := strings.IndexByte(path, '@'); i >= 0 {
+		pinText = path[i+1:]
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, "/")
+	if len(segments) != 3 || segments[0] == "" || segments[1] == "" || segments[2] == "" {
+		return remoteModuleRef{}, fmt.Errorf("ferret: get: %q doesn't look like <host>/<user>/<repo>[@pin]", ref)
+	}
+
+	host := segments[0]
+	backend := backendGitClone
+	for _, allowed := range remoteModuleHosts {
+		if host == allowed {
+			backend = backendReleaseAPI
+			break
+		}
+	}
+	if backend == backendGitClone && !looksLikeAHost(host) {
+		return remoteModuleRef{}, fmt.Errorf("ferret: get: %q doesn't look like a git host; expected a domain "+
+			"with a release API (%s) or a plain domain to shallow-clone from",
+			host, strings.Join(remoteModuleHosts, ", "))
+	}
+
+	pin, err := parsePin(pinText)
+	if err != nil {
+		return remoteModuleRef{}, fmt.Errorf("ferret: get: %q: %w", ref, err)
+	}
+	if backend == backendGitClone && pin.kind == pinCommit {
+		return remoteModuleRef{}, fmt.Errorf("ferret: get: %q: %s has no release API, so it would be fetched "+
+			"with a shallow clone, which can't check out an arbitrary commit — pin a branch or tag instead",
+			ref, host)
+	}
+
+	return remoteModuleRef{host: host, path: segments[1] + "/" + segments[2], pin: pin, backend: backend}, nil
+}
+
+// looksLikeAHost reports whether s is shaped like a domain name: at least
+// one dot, and nothing that couldn't appear in one.
+func looksLikeAHost(s string) bool {
+	if !strings.Contains(s, ".") {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// parsePin parses a reference's @-suffix. An empty suffix (no @ at all)
+// means "latest", represented the same way update.go would want a fully
+// unconstrained dependency to read: an empty semver.Constraint, which
+// Matches every version.
+func parsePin(s string) (modulePin, error) {
+	switch {
+	case s == "":
+		return modulePin{kind: pinVersion}, nil
+	case strings.HasPrefix(s, "branch:"):
+		name := strings.TrimPrefix(s, "branch:")
+		if name == "" {
+			return modulePin{}, fmt.Errorf("branch pin has no branch name")
+		}
+		return modulePin{kind: pinBranch, name: name}, nil
+	case strings.HasPrefix(s, "commit:"):
+		sha := strings.TrimPrefix(s, "commit:")
+		if !isHexCommitSHA(sha) {
+			return modulePin{}, fmt.Errorf("commit pin %q is not a hex commit SHA", sha)
+		}
+		return modulePin{kind: pinCommit, name: sha}, nil
+	default:
+		c, err := semver.ParseConstraint(s)
+		if err != nil {
+			return modulePin{}, err
+		}
+		return modulePin{kind: pinVersion, constraint: c}, nil
+	}
+}
+
+// isHexCommitSHA reports whether sha looks like a git commit SHA: 7 to 40
+// lowercase hex characters, covering both full SHAs and the short form
+// `git rev-parse --short` produces.
+func isHexCommitSHA(sha string) bool {
+	if len(sha) < 7 || len(sha) > 40 {
+		return false
+	}
+	for _, r := range sha {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunGetAcceptsTheOfflineFlag(t *testing.T) {
+	err := runGet([]string{"-offline", "github.com/user/repo@v1.0.0"})
+	if err == nil {
+		t.Fatal("expected an error (no download backend exists)")
+	}
+	if code, ok := ExitCode(err); !ok || code != exitDependency {
+		t.Errorf("got (%d, %v), want (%d, true)", code, ok, exitDependency)
+	}
+}
+
+func TestResolveProxyReturnsEmptyWhenUnset(t *testing.T) {
+	t.Setenv("FERRET_PROXY", "")
+	proxy, err := resolveProxy()
+	if err != nil {
+		t.Fatalf("resolveProxy: %v", err)
+	}
+	if proxy != "" {
+		t.Errorf("got %q, want empty", proxy)
+	}
+}
+
+func TestResolveProxyAcceptsAnAbsoluteURL(t *testing.T) {
+	t.Setenv("FERRET_PROXY", "https://proxy.example.com")
+	proxy, err := resolveProxy()
+	if err != nil {
+		t.Fatalf("resolveProxy: %v", err)
+	}
+	if proxy != "https://proxy.example.com" {
+		t.Errorf("got %q", proxy)
+	}
+}
+
+func TestResolveProxyRejectsARelativeURL(t *testing.T) {
+	t.Setenv("FERRET_PROXY", "not-a-url")
+	if _, err := resolveProxy(); err == nil {
+		t.Error("expected an error for a non-absolute FERRET_PROXY")
+	}
+}
+
+func TestRunGetMentionsTheProxyWhenSet(t *testing.T) {
+	t.Setenv("FERRET_PROXY", "https://proxy.example.com")
+	err := runGet([]string{"github.com/user/repo@v1.0.0"})
+	if err == nil || !strings.Contains(err.Error(), "proxy.example.com") {
+		t.Errorf("expected the configured proxy to be mentioned, got %v", err)
+	}
+}
+
+func TestRunGetRejectsExtraArgs(t *testing.T) {
+	err := runGet([]string{"a", "b"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret get") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
+
+func TestRunGetRejectsAMalformedReference(t *testing.T) {
+	err := runGet([]string{"not-a-module-path"})
+	if err == nil || !strings.Contains(err.Error(), "doesn't look like") {
+		t.Errorf("expected a malformed-reference error, got %v", err)
+	}
+}
+
+func TestRunGetRejectsAHostThatIsNotEvenShapedLikeADomain(t *testing.T) {
+	err := runGet([]string{"localhost/user/repo@v1.0.0"})
+	if err == nil || !strings.Contains(err.Error(), "doesn't look like a git host") {
+		t.Errorf("expected a not-a-host error, got %v", err)
+	}
+}
+
+func TestRunGetFallsBackToAShallowCloneForAnUnrecognizedDomain(t *testing.T) {
+	err := runGet([]string{"sr.ht/user/repo@v1.0.0"})
+	if err == nil || !strings.Contains(err.Error(), "a shallow git clone") {
+		t.Errorf("expected a shallow-git-clone backend, got %v", err)
+	}
+	code, ok := ExitCode(err)
+	if !ok || code != exitDependency {
+		t.Errorf("got (%d, %v), want (%d, true)", code, ok, exitDependency)
+	}
+}
+
+func TestRunGetRejectsACommitPinOnTheGitCloneBackend(t *testing.T) {
+	err := runGet([]string{"sr.ht/user/repo@commit:abc1234"})
+	if err == nil || !strings.Contains(err.Error(), "can't check out an arbitrary commit") {
+		t.Errorf("expected a shallow-clone commit-pin error, got %v", err)
+	}
+}
+
+func TestRunGetAllowsACommitPinOnTheReleaseAPIBackend(t *testing.T) {
+	err := runGet([]string{"github.com/user/repo@commit:abc1234"})
+	if err == nil || !strings.Contains(err.Error(), "its release API") {
+		t.Errorf("expected the release-API backend, got %v", err)
+	}
+}
+
+func TestRunGetRecognizesEachSupportedHost(t *testing.T) {
+	for _, host := range remoteModuleHosts {
+		err := runGet([]string{host + "/user/repo@v1.0.0"})
+		if err == nil {
+			t.Fatalf("%s: expected an error (no download backend exists)", host)
+		}
+		if !strings.Contains(err.Error(), "recognized module reference") {
+			t.Errorf("%s: expected the reference to be recognized, got %v", host, err)
+		}
+		code, ok := ExitCode(err)
+		if !ok || code != exitDependency {
+			t.Errorf("%s: got (%d, %v), want (%d, true)", host, code, ok, exitDependency)
+		}
+	}
+}
+
+func TestParsePinDefaultsToLatestOnAnEmptySuffix(t *testing.T) {
+	pin, err := parsePin("")
+	if err != nil {
+		t.Fatalf("parsePin: %v", err)
+	}
+	if pin.String() != "latest" {
+		t.Errorf("got %q, want latest", pin.String())
+	}
+}
+
+func TestParsePinAcceptsASemverConstraint(t *testing.T) {
+	pin, err := parsePin("^1.2.3")
+	if err != nil {
+		t.Fatalf("parsePin: %v", err)
+	}
+	if pin.kind != pinVersion {
+		t.Errorf("expected pinVersion, got %v", pin.kind)
+	}
+}
+
+func TestParsePinAcceptsABranchName(t *testing.T) {
+	pin, err := parsePin("branch:main")
+	if err != nil {
+		t.Fatalf("parsePin: %v", err)
+	}
+	if pin.kind != pinBranch || pin.String() != "branch:main" {
+		t.Errorf("got %+v, want a branch pin on main", pin)
+	}
+}
+
+func TestParsePinRejectsAnEmptyBranchName(t *testing.T) {
+	if _, err := parsePin("branch:"); err == nil {
+		t.Error("expected an error for an empty branch name")
+	}
+}
+
+func TestParsePinAcceptsACommitSHA(t *testing.T) {
+	pin, err := parsePin("commit:abc1234")
+	if err != nil {
+		t.Fatalf("parsePin: %v", err)
+	}
+	if pin.kind != pinCommit || pin.String() != "commit:abc1234" {
+		t.Errorf("got %+v, want a commit pin on abc1234", pin)
+	}
+}
+
+func TestParsePinRejectsANonHexCommitSHA(t *testing.T) {
+	if _, err := parsePin("commit:not-hex!"); err == nil {
+		t.Error("expected an error for a non-hex commit SHA")
+	}
+}
+
+func TestParsePinRejectsAnInvalidSemverConstraint(t *testing.T) {
+	if _, err := parsePin("not-a-version"); err == nil {
+		t.Error("expected an error for an invalid semver constraint")
+	}
+}
+
+func TestParseModuleRefRecognizesABranchPin(t *testing.T) {
+	ref, err := parseModuleRef("github.com/user/repo@branch:main")
+	if err != nil {
+		t.Fatalf("parseModuleRef: %v", err)
+	}
+	if ref.host != "github.com" || ref.path != "user/repo" || ref.pin.kind != pinBranch {
+		t.Errorf("got %+v", ref)
+	}
+}
+
+func TestParseModuleRefRejectsAMalformedPin(t *testing.T) {
+	if _, err := parseModuleRef("github.com/user/repo@commit:!!!"); err == nil {
+		t.Error("expected an error for a malformed commit pin")
+	}
+}
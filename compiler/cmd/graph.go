@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+)
+
+// runGraph implements `ferret graph`: print the module dependency graph
+// and, once one exists, the external package graph from the lockfile, as
+// DOT or JSON with cycle annotations.
+//
+// Neither graph exists to print. There's no import declaration anywhere
+// in the AST (see compiler/ast) for a module to name a dependency with, so
+// there's nothing resembling ctx.DepGraph yet — build()'s own TODO is
+// where the module graph is expected to first show up, once modules can
+// import each other at all. There's also no lockfile format or package
+// manager (see the backlog's package-manager requests), so the external
+// half of this command has no input either. This validates its own flags
+// for real and fails with that explanation rather than printing an empty
+// or fabricated graph.
+func runGraph(args []string) error {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	format := fs.String("format", "dot", "output format: dot or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageErrorf("usage: ferret graph [-format dot|json]")
+	}
+	switch *format {
+	case "dot", "json":
+	default:
+		return fmt.Errorf("ferret: -format must be dot or json, got %q", *format)
+	}
+
+	return fmt.Errorf("ferret: graph: nothing to graph yet: there's no import syntax for a module to declare a " +
+		"dependency with, so no dependency graph exists to print (see the TODO in compiler/cmd/build.go), " +
+		"and no lockfile format exists for the external package graph either")
+}
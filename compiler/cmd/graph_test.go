@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunGraphRejectsUnknownFormat(t *testing.T) {
+	err := runGraph([]string{"-format", "yaml"})
+	if err == nil || !strings.Contains(err.Error(), "-format must be dot or json") {
+		t.Errorf("expected an unknown -format to be rejected, got %v", err)
+	}
+}
+
+func TestRunGraphRejectsExtraArgs(t *testing.T) {
+	err := runGraph([]string{"extra"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret graph") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
+
+func TestRunGraphExplainsThereIsNoGraphYet(t *testing.T) {
+	err := runGraph(nil)
+	if err == nil || !strings.Contains(err.Error(), "no import syntax") {
+		t.Errorf("expected an explanation that no dependency graph exists yet, got %v", err)
+	}
+}
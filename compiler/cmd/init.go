@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// initTemplate names one of the starting points `ferret init -template`
+// can scaffold.
+type initTemplate string
+
+const (
+	templateHelloWorld initTemplate = "hello-world"
+	templateCLIApp     initTemplate = "cli-app"
+	templateLibrary    initTemplate = "library"
+)
+
+// runInit implements `ferret init`: scaffold a new project directory
+// instead of only writing a bare fer.ret the way it used to.
+//
+// There's no fer.ret manifest format defined anywhere yet, so the fer.ret
+// this writes is a placeholder: a name field and nothing else, ready to
+// grow once a real format exists. Likewise there's no lexer/parser yet to
+// confirm Ferret's concrete surface syntax (see the TODO in build()); the
+// .fer files this writes stick to the "fn name() { ... }" convention
+// implied by existing error messages like "fn main() must take no
+// parameters" (see x86.ValidateEntryPoint) and nothing more elaborate,
+// since that's the only syntax convention anything in this repo has
+// actually committed to — no string literals, imports or typed parameter
+// syntax exist in the AST yet for a template to use with any confidence.
+func runInit(args []string) error {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	template := fs.String("template", string(templateHelloWorld), "project template: hello-world, cli-app or library")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("usage: ferret init [-template hello-world|cli-app|library] <directory>")
+	}
+	dir := fs.Arg(0)
+	name := filepath.Base(filepath.Clean(dir))
+
+	var files map[string]string
+	switch initTemplate(*template) {
+	case templateHelloWorld:
+		files = helloWorldTemplate(name)
+	case templateCLIApp:
+		files = cliAppTemplate(name)
+	case templateLibrary:
+		files = libraryTemplate(name)
+	default:
+		return fmt.Errorf("ferret: -template must be hello-world, cli-app or library, got %q", *template)
+	}
+
+	for relPath, content := range files {
+		path := filepath.Join(dir, relPath)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("ferret: init: creating %s: %w", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("ferret: init: writing %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// ferRetConfig is the placeholder fer.ret content every template writes;
+// see runInit's doc comment for why it's just a name field today.
+func ferRetConfig(name string) string {
+	return fmt.Sprintf("name = %q\n", name)
+}
+
+// mainEntryPoint is the minimal `fn main()` body every template with an
+// entry point shares, returning 0 for success the way a C/Go main would.
+const mainEntryPoint = "fn main() {\n\treturn 0\n}\n"
+
+func helloWorldTemplate(name string) map[string]string {
+	return map[string]string{
+		"fer.ret":  ferRetConfig(name),
+		"main.fer": mainEntryPoint,
+	}
+}
+
+func cliAppTemplate(name string) map[string]string {
+	return map[string]string{
+		"fer.ret":          ferRetConfig(name),
+		"main.fer":         mainEntryPoint,
+		"internal/app.fer": "fn run() {\n\treturn 0\n}\n",
+	}
+}
+
+func libraryTemplate(name string) map[string]string {
+	return map[string]string{
+		"fer.ret": ferRetConfig(name),
+		"lib.fer": "fn example() {\n\treturn 0\n}\n",
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunInitDefaultsToHelloWorld(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "myproj")
+	if err := runInit([]string{dir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "main.fer"), "fn main()")
+	assertFileContains(t, filepath.Join(dir, "fer.ret"), `"myproj"`)
+}
+
+func TestRunInitCLIAppScaffoldsAnInternalPackage(t *testing.T) {
+	dir := t.TempDir()
+	if err := runInit([]string{"-template", "cli-app", dir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertFileContains(t, filepath.Join(dir, "main.fer"), "fn main()")
+	assertFileContains(t, filepath.Join(dir, "internal/app.fer"), "fn run()")
+}
+
+func TestRunInitLibraryHasNoMain(t *testing.T) {
+	dir := t.TempDir()
+	if err := runInit([]string{"-template", "library", dir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "main.fer")); err == nil {
+		t.Errorf("expected a library template not to scaffold a main.fer")
+	}
+	assertFileContains(t, filepath.Join(dir, "lib.fer"), "fn example()")
+}
+
+func TestRunInitRejectsUnknownTemplate(t *testing.T) {
+	err := runInit([]string{"-template", "bogus", t.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "-template must be hello-world, cli-app or library") {
+		t.Errorf("expected an unknown -template to be rejected, got %v", err)
+	}
+}
+
+func TestRunInitRejectsExtraArgs(t *testing.T) {
+	err := runInit([]string{"a", "b"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret init") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
+
+func assertFileContains(t *testing.T, path, want string) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	if !strings.Contains(string(content), want) {
+		t.Errorf("expected %s to contain %q, got %q", path, want, content)
+	}
+}
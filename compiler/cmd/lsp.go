@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/itsfuad/ferret-compiler/compiler/lsp"
+)
+
+// runLSP implements `ferret lsp`: starting a Language Server Protocol
+// session over stdio. Ferret ships one binary with subcommands rather than
+// a separate lsp/main.go, so the server is reached the same way build and
+// explain are.
+//
+// There's no TCP mode to add an idle timeout to — this is the only way
+// `ferret lsp` ever starts a session. stdio mode doesn't need one anyway:
+// s.Serve returns as soon as os.Stdin reaches EOF, which happens as soon
+// as the owning client process exits or closes the pipe, so an orphaned
+// server here just ends on its own rather than lingering.
+func runLSP(args []string) error {
+	if len(args) != 0 {
+		return usageErrorf("usage: ferret lsp")
+	}
+
+	s := lsp.New()
+	documents := lsp.NewDocuments()
+	lsp.RegisterDocumentSync(s, documents)
+	lsp.RegisterDidClose(s, documents)
+	lsp.RegisterDefinition(s)
+	lsp.RegisterHover(s)
+	lsp.RegisterCompletion(s)
+	lsp.RegisterReferences(s)
+	lsp.RegisterRename(s)
+	lsp.RegisterDocumentSymbol(s)
+	lsp.RegisterWorkspaceSymbol(s)
+	lsp.RegisterSemanticTokens(s)
+	lsp.RegisterSignatureHelp(s)
+	lsp.RegisterCodeAction(s)
+	lsp.RegisterFormatting(s)
+	lsp.RegisterFoldingRange(s)
+	lsp.RegisterInlayHint(s)
+	lsp.RegisterTypeHierarchy(s)
+	lsp.RegisterDocumentHighlight(s)
+	lsp.RegisterSelectionRange(s)
+	lsp.RegisterTypeDefinition(s)
+	lsp.RegisterConfiguration(s)
+	lsp.RegisterWatchedFiles(s)
+	lsp.RegisterTrace(s)
+	lsp.RegisterLifecycle(s)
+	return s.Serve(os.Stdin, os.Stdout)
+}
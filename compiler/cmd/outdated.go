@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+)
+
+// runOutdated implements `ferret outdated`: list every direct and indirect
+// dependency with its installed, latest-compatible, and latest-overall
+// version, as a table or as JSON.
+//
+// There's no dependency list (direct or indirect), no installed-version
+// record, and no registry lookup (CheckForAvailableUpdates) to compare
+// against — no package manager exists yet at all. This validates its own
+// flags for real and then explains that, rather than printing an empty
+// table that looks like "nothing is outdated".
+func runOutdated(args []string) error {
+	fs := flag.NewFlagSet("outdated", flag.ContinueOnError)
+	jsonFlag := fs.Bool("json", false, "print the report as JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageErrorf("usage: ferret outdated [-json]")
+	}
+	_ = jsonFlag
+
+	return fmt.Errorf("ferret: outdated: there are no dependencies to check yet; " +
+		"this repo has no package manager substrate at all")
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunOutdatedRejectsExtraArgs(t *testing.T) {
+	err := runOutdated([]string{"extra"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret outdated") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
+
+func TestRunOutdatedExplainsThereAreNoDependenciesYet(t *testing.T) {
+	err := runOutdated(nil)
+	if err == nil || !strings.Contains(err.Error(), "no dependencies") {
+		t.Errorf("expected an explanation that no dependencies exist yet, got %v", err)
+	}
+}
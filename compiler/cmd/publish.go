@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+)
+
+// runPublish implements `ferret publish`: validate a module (it builds
+// cleanly, its fer.ret metadata is complete, sharing is enabled), compute
+// the next version tag, and create/push that git tag.
+//
+// "Builds cleanly" is the one part of this with something real behind
+// it — publish runs the same check() entry-point validation `ferret
+// check` does before going any further, since a module that can't build
+// obviously isn't publishable. Everything after that needs fer.ret
+// metadata and a next-version computation that don't exist yet (no
+// package manager exists yet at all), so publish stops there with an
+// explanation instead of tagging and pushing a release with no metadata
+// behind it.
+func runPublish(args []string) error {
+	fs := flag.NewFlagSet("publish", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "validate and print what would be tagged, without creating or pushing a tag")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("usage: ferret publish [-dry-run] <file.fer>")
+	}
+	_ = dryRun
+
+	input := fs.Arg(0)
+	if err := check(input); err != nil {
+		return fmt.Errorf("ferret: publish: %s doesn't build cleanly, refusing to publish it: %w", input, err)
+	}
+
+	return fmt.Errorf("ferret: publish: %s builds cleanly, but there's no fer.ret metadata, share flag, or "+
+		"version history to compute a next tag from yet; this repo has no package manager substrate at all", input)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRunPublishRejectsAModuleThatDoesNotBuild(t *testing.T) {
+	dir := t.TempDir()
+	input := dir + "/prog.fer"
+	if err := os.WriteFile(input, []byte(""), 0o644); err != nil {
+		t.Fatalf("writing fixture source: %v", err)
+	}
+
+	err := runPublish([]string{input})
+	if err == nil || !strings.Contains(err.Error(), "doesn't build cleanly") {
+		t.Errorf("expected a build-failure error, got %v", err)
+	}
+}
+
+func TestRunPublishRejectsAMissingFile(t *testing.T) {
+	err := runPublish([]string{"does-not-exist.fer"})
+	if err == nil || !strings.Contains(err.Error(), "reading does-not-exist.fer") {
+		t.Errorf("expected a missing-file error, got %v", err)
+	}
+}
+
+func TestRunPublishRejectsExtraArgs(t *testing.T) {
+	err := runPublish([]string{"a", "b"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret publish") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+
+	"github.com/itsfuad/ferret-compiler/compiler/report"
+	"github.com/itsfuad/ferret-compiler/compiler/target"
+)
+
+// runRun implements `ferret run`: build the entry point to a temporary
+// binary and execute it, the way `go run` builds to a temp binary rather
+// than leaving one behind. It shares every build flag with `ferret build`
+// except -o, since the output path isn't the caller's to choose.
+func runRun(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	emitAsm := fs.Bool("emit-asm", false, "write the generated x86-64 assembly next to a kept build (implies -save-temps)")
+	optLevel := fs.Int("O", 0, "optimization level (0, 1 or 2)")
+	targetTriple := fs.String("target", "", "target triple, e.g. x86_64-linux-gnu, x86_64-pc-windows-msvc, x86_64-apple-darwin")
+	pic := fs.Bool("fpic", false, "generate a position-independent executable")
+	diagnosticsFormat := fs.String("diagnostics-format", "text", "diagnostics output format: text, json, ndjson or sarif")
+	jsonFlag := fs.Bool("json", false, "shorthand for -diagnostics-format ndjson")
+	maxErrors := fs.Int("max-errors", 20, "stop showing individual errors after this many and print a summary instead (0 means no limit); only applies to -diagnostics-format text")
+	werror := fs.Bool("werror", false, "treat warnings as errors")
+	color := fs.String("color", "auto", "color diagnostics output: auto, always or never; only applies to -diagnostics-format text")
+	watchFlag := fs.Bool("watch", false, "rebuild and rerun whenever the input file changes, until interrupted")
+	quiet := fs.Bool("quiet", false, "suppress routine status messages (e.g. -watch's rebuild timing); errors still print")
+	verboseFlag := fs.Bool("verbose", false, "the opposite of -quiet; also settable via FERRET_LOG")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("usage: ferret run [-O level] [-target triple] [-fpic] [-diagnostics-format text|json|ndjson|sarif] [-json] [-max-errors N] [-werror] [-color auto|always|never] [-watch] [-quiet] [-verbose] <file.fer>")
+	}
+	level, err := resolveVerbosity(*quiet, *verboseFlag)
+	if err != nil {
+		return err
+	}
+	resolvedFormat, err := resolveDiagnosticsFormat(*jsonFlag, *diagnosticsFormat)
+	if err != nil {
+		return err
+	}
+	diagnosticsFormat = &resolvedFormat
+	if *maxErrors < 0 {
+		return fmt.Errorf("ferret: -max-errors must be >= 0, got %d", *maxErrors)
+	}
+	colorMode := report.ColorMode(*color)
+	switch colorMode {
+	case report.ColorAuto, report.ColorAlways, report.ColorNever:
+	default:
+		return fmt.Errorf("ferret: -color must be auto, always or never, got %q", *color)
+	}
+
+	t, err := target.Parse(*targetTriple)
+	if err != nil {
+		return fmt.Errorf("ferret: %w", err)
+	}
+	if !hostMatchesTarget(t) {
+		return fmt.Errorf("ferret: run: can't execute a %s binary on this host; use ferret build and run it on the target machine instead", *targetTriple)
+	}
+	if *optLevel < 0 || *optLevel > 2 {
+		return fmt.Errorf("ferret: -O must be 0, 1 or 2, got %d", *optLevel)
+	}
+
+	dir, err := os.MkdirTemp("", "ferret-run-")
+	if err != nil {
+		return fmt.Errorf("ferret: run: creating temp directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	opts := buildOptions{
+		input:    fs.Arg(0),
+		output:   dir + "/run",
+		emitAsm:  *emitAsm,
+		optLevel: *optLevel,
+		target:   t,
+		pic:      *pic,
+	}
+
+	buildAndRun := func() error {
+		if err := buildAndDiagnose(opts, *diagnosticsFormat, *maxErrors, *werror, colorMode); err != nil {
+			return err
+		}
+
+		child := exec.Command(opts.output)
+		child.Stdin = os.Stdin
+		child.Stdout = os.Stdout
+		child.Stderr = os.Stderr
+		if err := child.Run(); err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				return exitCodeError(exitErr.ExitCode())
+			}
+			return fmt.Errorf("ferret: run: %w", err)
+		}
+		return nil
+	}
+
+	if *watchFlag {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		return watch(ctx, opts.input, buildAndRun, os.Stdout, level)
+	}
+
+	return buildAndRun()
+}
+
+// hostMatchesTarget reports whether t is the OS ferret run is executing
+// on, since running a cross-compiled binary for another OS makes no sense
+// without an emulator this toolchain doesn't provide.
+func hostMatchesTarget(t target.Target) bool {
+	switch t.OS {
+	case target.Windows:
+		return runtime.GOOS == "windows"
+	case target.MacOS:
+		return runtime.GOOS == "darwin"
+	default:
+		return runtime.GOOS == "linux"
+	}
+}
+
+// exitCodeError carries a child process's exit code so main can propagate
+// it verbatim instead of always exiting 1 the way a plain error does.
+type exitCodeError int
+
+func (e exitCodeError) Error() string {
+	return fmt.Sprintf("ferret: run: program exited with status %d", int(e))
+}
+
+// ExitCode returns the status run should exit with for this error, for
+// main to recognize via errors.As.
+func (e exitCodeError) ExitCode() int {
+	return int(e)
+}
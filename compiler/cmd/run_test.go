@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRunRejectsOutOfRangeOptLevel(t *testing.T) {
+	err := runRun([]string{"-O", "3", "prog.fer"})
+	if err == nil || !strings.Contains(err.Error(), "-O must be 0, 1 or 2") {
+		t.Errorf("expected an out-of-range -O to be rejected, got %v", err)
+	}
+}
+
+func TestRunRejectsUnknownDiagnosticsFormat(t *testing.T) {
+	err := runRun([]string{"-diagnostics-format", "xml", "prog.fer"})
+	if err == nil || !strings.Contains(err.Error(), "-diagnostics-format must be text, json, ndjson or sarif") {
+		t.Errorf("expected an unknown -diagnostics-format to be rejected, got %v", err)
+	}
+}
+
+func TestRunRejectsUnknownColorMode(t *testing.T) {
+	err := runRun([]string{"-color", "rainbow", "prog.fer"})
+	if err == nil || !strings.Contains(err.Error(), "-color must be auto, always or never") {
+		t.Errorf("expected an unknown -color to be rejected, got %v", err)
+	}
+}
+
+func TestRunRejectsACrossCompiledTarget(t *testing.T) {
+	otherTriple := "x86_64-pc-windows-msvc"
+	if runtime.GOOS == "windows" {
+		otherTriple = "x86_64-apple-darwin"
+	}
+
+	err := runRun([]string{"-target", otherTriple, "prog.fer"})
+	if err == nil || !strings.Contains(err.Error(), "can't execute a") {
+		t.Errorf("expected a cross-compiled target to be rejected, got %v", err)
+	}
+}
+
+func TestExitCodeRecognizesExitCodeError(t *testing.T) {
+	code, ok := ExitCode(exitCodeError(7))
+	if !ok || code != 7 {
+		t.Errorf("ExitCode(exitCodeError(7)) = %d, %v, want 7, true", code, ok)
+	}
+}
+
+func TestExitCodeIsFalseForAPlainError(t *testing.T) {
+	if _, ok := ExitCode(errors.New("boom")); ok {
+		t.Errorf("expected a plain error not to report an exit code")
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// testFileSuffix marks a source file as a test file, the way _test.go does
+// for go test.
+const testFileSuffix = "_test.fer"
+
+// discoverTestFiles walks root for files ending in testFileSuffix, returning
+// their paths sorted for deterministic output.
+func discoverTestFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if strings.HasSuffix(path, testFileSuffix) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ferret: test: walking %s: %w", root, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// runTest implements `ferret test`: discover *_test.fer files under the
+// given directory (or the current one) and run their test_* functions.
+//
+// Discovery is real and works today. Running them isn't: there's no
+// lexer/parser to read a test_* function out of a test file (see the
+// frontend TODOs in build.go) and no test harness runtime analogous to
+// runtime/rt for invoking one and capturing pass/fail, so this stops at
+// reporting what it found instead of pretending to run it.
+func runTest(args []string) error {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usageErrorf("usage: ferret test [directory]")
+	}
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+
+	files, err := discoverTestFiles(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("ferret: test: no %s files found under %s", testFileSuffix, dir)
+	}
+	return fmt.Errorf("ferret: test: found %d test file(s) but can't run them yet: "+
+		"there's no frontend to parse a test_* function out of them (see compiler/cmd/build.go's TODOs)\n%s",
+		len(files), strings.Join(files, "\n"))
+}
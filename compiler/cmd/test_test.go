@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiscoverTestFilesFindsNestedTestFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "prog.fer"), nil, 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "prog_test.fer"), nil, 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+	sub := filepath.Join(dir, "pkg")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatalf("making fixture dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, "other_test.fer"), nil, 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	files, err := discoverTestFiles(dir)
+	if err != nil {
+		t.Fatalf("discoverTestFiles: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 test files, got %v", files)
+	}
+	for _, f := range files {
+		if !strings.HasSuffix(f, "_test.fer") {
+			t.Errorf("expected only _test.fer files, got %q", f)
+		}
+	}
+}
+
+func TestDiscoverTestFilesIgnoresNonTestFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "prog.fer"), nil, 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	files, err := discoverTestFiles(dir)
+	if err != nil {
+		t.Fatalf("discoverTestFiles: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected no test files, got %v", files)
+	}
+}
+
+func TestRunTestReportsNoTestFiles(t *testing.T) {
+	dir := t.TempDir()
+	err := runTest([]string{dir})
+	if err == nil || !strings.Contains(err.Error(), "no _test.fer files found") {
+		t.Errorf("expected a no-test-files error, got %v", err)
+	}
+}
+
+func TestRunTestReportsDiscoveredFilesCantRunYet(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "prog_test.fer"), nil, 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	err := runTest([]string{dir})
+	if err == nil || !strings.Contains(err.Error(), "found 1 test file") || !strings.Contains(err.Error(), "prog_test.fer") {
+		t.Errorf("expected a can't-run-yet error naming the file, got %v", err)
+	}
+}
+
+func TestRunTestRejectsExtraArgs(t *testing.T) {
+	err := runTest([]string{"a", "b"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret test") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
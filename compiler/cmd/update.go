@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+)
+
+// runUpdate implements `ferret update`: bump one or all dependencies in
+// fer.ret to the latest version compatible with the requested bump size,
+// printing a diff of fer.ret and lockfile changes before applying it.
+//
+// There's no DependencyManager, fer.ret, or lockfile anywhere in this repo
+// to update — no package manager exists yet at all (see the package-
+// manager TODOs elsewhere in this backlog). This validates its own flags
+// for real and then explains that, rather than updating nothing and
+// claiming success.
+func runUpdate(args []string) error {
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	major := fs.Bool("major", false, "allow a major version bump")
+	minor := fs.Bool("minor", false, "allow a minor version bump")
+	patch := fs.Bool("patch", false, "allow a patch version bump")
+	dryRun := fs.Bool("dry-run", false, "print the change without applying it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return usageErrorf("usage: ferret update [package] [-major|-minor|-patch] [-dry-run]")
+	}
+	if countSet(*major, *minor, *patch) > 1 {
+		return fmt.Errorf("ferret: -major, -minor and -patch are mutually exclusive")
+	}
+	_ = dryRun
+
+	return fmt.Errorf("ferret: update: there's no dependency manager, fer.ret or lockfile to update yet; " +
+		"this repo has no package manager substrate at all")
+}
+
+// countSet returns how many of the given bools are true.
+func countSet(bs ...bool) int {
+	n := 0
+	for _, b := range bs {
+		if b {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunUpdateRejectsConflictingBumpFlags(t *testing.T) {
+	err := runUpdate([]string{"-major", "-minor"})
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("expected conflicting bump flags to be rejected, got %v", err)
+	}
+}
+
+func TestRunUpdateRejectsExtraArgs(t *testing.T) {
+	err := runUpdate([]string{"a", "b"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret update") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
+
+func TestRunUpdateExplainsThereIsNoDependencyManagerYet(t *testing.T) {
+	err := runUpdate(nil)
+	if err == nil || !strings.Contains(err.Error(), "no dependency manager") {
+		t.Errorf("expected an explanation that no dependency manager exists yet, got %v", err)
+	}
+}
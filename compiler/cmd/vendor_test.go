@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunVendorRejectsExtraArgs(t *testing.T) {
+	err := runVendor([]string{"extra"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret vendor") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
+
+func TestRunVendorExplainsThereIsNoDependencyCacheYet(t *testing.T) {
+	err := runVendor(nil)
+	if err == nil || !strings.Contains(err.Error(), "no dependency cache") {
+		t.Errorf("expected an explanation that no dependency cache exists yet, got %v", err)
+	}
+}
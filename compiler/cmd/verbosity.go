@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// verbosity controls how much of ferret's own incidental output (e.g.
+// watch's rebuild timing) a command prints. It's separate from
+// -diagnostics-format/-werror, which already control diagnostics output
+// independently of this — verbosity only affects messages like "rebuilt in
+// 40ms" that aren't diagnostics at all.
+type verbosity int
+
+const (
+	// verbosityQuiet suppresses routine status messages; errors still
+	// print regardless, since -quiet means "stop telling me what's
+	// working", not "stop telling me what broke".
+	verbosityQuiet verbosity = iota
+	verbosityNormal
+	// verbosityVerbose exists for FERRET_LOG=verbose/-verbose to resolve
+	// to, but nothing prints any more at it today than at verbosityNormal
+	// — there's no extra detail ferret's incidental output has to give
+	// yet, the same way -O2 had nothing beyond -O1 until Peephole existed.
+	verbosityVerbose
+)
+
+// resolveVerbosity turns -quiet/-verbose flags and FERRET_LOG into a
+// verbosity, with an explicit flag always taking precedence over the
+// environment variable. FERRET_LOG accepts error/warn as aliases for quiet
+// and debug as an alias for verbose, so a value chosen for a leveled
+// logger elsewhere still means something here.
+func resolveVerbosity(quiet, verboseFlag bool) (verbosity, error) {
+	if quiet && verboseFlag {
+		return verbosityNormal, fmt.Errorf("ferret: -quiet and -verbose are mutually exclusive")
+	}
+	if quiet {
+		return verbosityQuiet, nil
+	}
+	if verboseFlag {
+		return verbosityVerbose, nil
+	}
+
+	switch env := os.Getenv("FERRET_LOG"); env {
+	case "quiet", "error", "warn":
+		return verbosityQuiet, nil
+	case "verbose", "debug":
+		return verbosityVerbose, nil
+	case "", "normal", "info":
+		return verbosityNormal, nil
+	default:
+		return verbosityNormal, fmt.Errorf("ferret: FERRET_LOG must be quiet, normal or verbose, got %q", env)
+	}
+}
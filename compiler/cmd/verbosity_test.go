@@ -0,0 +1,70 @@
+package cmd
+
+import "testing"
+
+func TestResolveVerbosityDefaultsToNormal(t *testing.T) {
+	v, err := resolveVerbosity(false, false)
+	if err != nil {
+		t.Fatalf("resolveVerbosity: %v", err)
+	}
+	if v != verbosityNormal {
+		t.Errorf("expected verbosityNormal, got %v", v)
+	}
+}
+
+func TestResolveVerbosityFlagsOverrideEachOther(t *testing.T) {
+	if v, err := resolveVerbosity(true, false); err != nil || v != verbosityQuiet {
+		t.Errorf("-quiet: got (%v, %v), want (verbosityQuiet, nil)", v, err)
+	}
+	if v, err := resolveVerbosity(false, true); err != nil || v != verbosityVerbose {
+		t.Errorf("-verbose: got (%v, %v), want (verbosityVerbose, nil)", v, err)
+	}
+}
+
+func TestResolveVerbosityRejectsQuietAndVerboseTogether(t *testing.T) {
+	if _, err := resolveVerbosity(true, true); err == nil {
+		t.Error("expected an error for -quiet and -verbose together")
+	}
+}
+
+func TestResolveVerbosityFlagTakesPrecedenceOverEnv(t *testing.T) {
+	t.Setenv("FERRET_LOG", "verbose")
+	v, err := resolveVerbosity(true, false)
+	if err != nil {
+		t.Fatalf("resolveVerbosity: %v", err)
+	}
+	if v != verbosityQuiet {
+		t.Errorf("expected the -quiet flag to win over FERRET_LOG=verbose, got %v", v)
+	}
+}
+
+func TestResolveVerbosityReadsFerretLogAliases(t *testing.T) {
+	cases := map[string]verbosity{
+		"":        verbosityNormal,
+		"normal":  verbosityNormal,
+		"info":    verbosityNormal,
+		"quiet":   verbosityQuiet,
+		"error":   verbosityQuiet,
+		"warn":    verbosityQuiet,
+		"verbose": verbosityVerbose,
+		"debug":   verbosityVerbose,
+	}
+	for env, want := range cases {
+		t.Setenv("FERRET_LOG", env)
+		got, err := resolveVerbosity(false, false)
+		if err != nil {
+			t.Errorf("FERRET_LOG=%q: %v", env, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("FERRET_LOG=%q: got %v, want %v", env, got, want)
+		}
+	}
+}
+
+func TestResolveVerbosityRejectsUnknownFerretLog(t *testing.T) {
+	t.Setenv("FERRET_LOG", "loud")
+	if _, err := resolveVerbosity(false, false); err == nil {
+		t.Error("expected an error for an unrecognized FERRET_LOG value")
+	}
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+)
+
+// runVerify implements `ferret verify`: recompute hashes of cached module
+// contents and compare them against the checksums recorded in
+// ferret.lock, reporting tampered, missing, or orphaned entries.
+//
+// There's no ferret.lock, no checksum field for it to record, and no
+// dependency cache to hash — no package manager exists yet at all. This
+// validates its own flags for real and then explains that, rather than
+// reporting a clean lockfile that was never checked.
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageErrorf("usage: ferret verify")
+	}
+
+	return fmt.Errorf("ferret: verify: there's no ferret.lock or dependency cache to verify yet; " +
+		"this repo has no package manager substrate at all")
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunVerifyRejectsExtraArgs(t *testing.T) {
+	err := runVerify([]string{"extra"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret verify") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
+
+func TestRunVerifyExplainsThereIsNoLockfileYet(t *testing.T) {
+	err := runVerify(nil)
+	if err == nil || !strings.Contains(err.Error(), "no ferret.lock") {
+		t.Errorf("expected an explanation that no lockfile exists yet, got %v", err)
+	}
+}
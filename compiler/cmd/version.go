@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/itsfuad/ferret-compiler/compiler/version"
+)
+
+// runVersion implements `ferret version`: print the compiler's own
+// version, commit, build date, and the target triples it can generate
+// code for. There's no `ferret init`/fer.ret manifest yet to validate a
+// project's required compiler version against — version.String and
+// version.SupportedTargets live in their own package rather than inside
+// cmd specifically so that validation can import and call them directly
+// once a manifest format exists, instead of ferret version being the
+// only place this information is rendered.
+func runVersion(args []string) error {
+	fs := flag.NewFlagSet("version", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageErrorf("usage: ferret version")
+	}
+
+	fmt.Printf("ferret %s\n", version.String())
+	fmt.Printf("supported targets: %s\n", strings.Join(version.SupportedTargets, ", "))
+	return nil
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunVersionRejectsExtraArgs(t *testing.T) {
+	err := runVersion([]string{"extra"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret version") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
+
+func TestRunVersionAcceptsNoArgs(t *testing.T) {
+	if err := runVersion(nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
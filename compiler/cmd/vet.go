@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+	"github.com/itsfuad/ferret-compiler/compiler/ir"
+	"github.com/itsfuad/ferret-compiler/compiler/report"
+)
+
+// lintRule is one independently enable/disable-able check `ferret vet`
+// runs over a module's declarations.
+type lintRule struct {
+	name    string
+	enabled *bool
+	check   func(fns []*ast.FunctionDecl) []report.Report
+}
+
+// runVet implements `ferret vet`: run every enabled lint rule over the
+// input module and print whatever they find as warnings, separate from
+// the hard errors `ferret check`/`ferret build` stop on — a module vet
+// flags is still a module that builds.
+//
+// Only lintUnreachableCode does real work today, reusing the same
+// reachability analysis the -O1 optimizer uses to drop dead blocks (see
+// ir.ReachableLabels) instead of silently discarding them. lintUnusedImports,
+// lintShadowedVariables and lintSuspiciousCasts are registered, get their
+// own disable flags, and always report nothing, because there's no import
+// table or AST scope/cast metadata yet for them to inspect (see the TODO in
+// build()); they're wired up now so adding their real logic later is a
+// one-function change instead of also needing new flags and plumbing.
+func runVet(args []string) error {
+	fs := flag.NewFlagSet("vet", flag.ContinueOnError)
+	unreachableCode := fs.Bool("unreachable-code", true, "warn about code no path can reach")
+	unusedImports := fs.Bool("unused-imports", true, "warn about imports nothing in the module references")
+	shadowedVariables := fs.Bool("shadowed-variables", true, "warn about a local that shadows an outer one")
+	suspiciousCasts := fs.Bool("suspicious-casts", true, "warn about casts likely to lose data or never succeed")
+	diagnosticsFormat := fs.String("diagnostics-format", "text", "diagnostics output format: text, json, ndjson or sarif")
+	jsonFlag := fs.Bool("json", false, "shorthand for -diagnostics-format ndjson")
+	color := fs.String("color", "auto", "color diagnostics output: auto, always or never; only applies to -diagnostics-format text")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("usage: ferret vet [-unreachable-code] [-unused-imports] [-shadowed-variables] [-suspicious-casts] [-diagnostics-format text|json|ndjson|sarif] [-json] [-color auto|always|never] <file.fer>")
+	}
+	resolvedFormat, err := resolveDiagnosticsFormat(*jsonFlag, *diagnosticsFormat)
+	if err != nil {
+		return err
+	}
+	colorMode := report.ColorMode(*color)
+	switch colorMode {
+	case report.ColorAuto, report.ColorAlways, report.ColorNever:
+	default:
+		return fmt.Errorf("ferret: -color must be auto, always or never, got %q", *color)
+	}
+
+	input := fs.Arg(0)
+	if _, err := os.ReadFile(input); err != nil {
+		return fmt.Errorf("ferret: reading %s: %w", input, err)
+	}
+
+	// TODO: parse input into fns once the frontend lands (see build()'s
+	// TODO); there's nothing for any rule to lint yet, same as check().
+	var fns []*ast.FunctionDecl
+
+	rules := []lintRule{
+		{"unreachable-code", unreachableCode, lintUnreachableCode},
+		{"unused-imports", unusedImports, lintUnusedImports},
+		{"shadowed-variables", shadowedVariables, lintShadowedVariables},
+		{"suspicious-casts", suspiciousCasts, lintSuspiciousCasts},
+	}
+
+	var findings []report.Report
+	for _, r := range rules {
+		if *r.enabled {
+			findings = append(findings, r.check(fns)...)
+		}
+	}
+
+	diagnostics := report.Reports(findings).Dedup().Sort()
+	var formatted string
+	switch resolvedFormat {
+	case "json":
+		formatted, err = report.FormatJSON(diagnostics)
+	case "ndjson":
+		formatted, err = report.FormatNDJSON(diagnostics)
+	case "sarif":
+		formatted, err = report.FormatSARIF(diagnostics)
+	default:
+		formatted, err = formatTextDiagnostics(diagnostics, 0, report.ResolveColor(colorMode, os.Stdout))
+	}
+	if err != nil {
+		return err
+	}
+	if formatted != "" {
+		fmt.Println(formatted)
+	}
+	return nil
+}
+
+// lintUnreachableCode warns about any IR block, in any function, that
+// ir.ReachableLabels can't reach from the entry block — e.g. statements
+// after an unconditional return. This is the one rule with real data to
+// work from: fns is an AST already lowered the same way build() lowers it
+// for codegen, so the check can run even though nothing produces an fns
+// with any entries yet.
+func lintUnreachableCode(fns []*ast.FunctionDecl) []report.Report {
+	var findings []report.Report
+	for _, fn := range fns {
+		lowered := ir.Lower(fn)
+		reachable := ir.ReachableLabels(lowered)
+		for _, blk := range lowered.Blocks {
+			if !reachable[blk.Label] {
+				findings = append(findings, report.Report{
+					Severity: report.SeverityWarning,
+					Code:     report.CodeUnreachableCode,
+					Message:  fmt.Sprintf("unreachable code in %s (block %s)", fn.Name, blk.Label),
+					Phase:    "vet",
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// lintUnusedImports would warn about an import nothing in the module
+// references, but there's no import table yet for any module to have —
+// see the TODO in build().
+func lintUnusedImports(fns []*ast.FunctionDecl) []report.Report {
+	return nil
+}
+
+// lintShadowedVariables would warn about a local declaration that shadows
+// one already in scope in an enclosing block, but the AST carries no scope
+// information yet to detect that with.
+func lintShadowedVariables(fns []*ast.FunctionDecl) []report.Report {
+	return nil
+}
+
+// lintSuspiciousCasts would warn about an explicit cast likely to lose data
+// (e.g. narrowing a wide integer) or that can never succeed (casting
+// between unrelated struct types), using analyzer.IsImplicitCastable as a
+// starting point — but there's no cast expression in the AST to walk yet.
+func lintSuspiciousCasts(fns []*ast.FunctionDecl) []report.Report {
+	return nil
+}
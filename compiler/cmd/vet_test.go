@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+func TestLintUnreachableCodeFindsAMergeBlockAfterBothBranchesReturn(t *testing.T) {
+	fns := []*ast.FunctionDecl{{
+		Name: "f",
+		Body: []ast.Stmt{
+			&ast.IfStmt{
+				Cond: &ast.IntLiteral{Value: 1},
+				Then: []ast.Stmt{&ast.ReturnStmt{Value: &ast.IntLiteral{Value: 1}}},
+				Else: []ast.Stmt{&ast.ReturnStmt{Value: &ast.IntLiteral{Value: 0}}},
+			},
+		},
+	}}
+
+	findings := lintUnreachableCode(fns)
+	if len(findings) != 1 {
+		t.Fatalf("expected one unreachable-code finding, got %d: %v", len(findings), findings)
+	}
+	if !strings.Contains(findings[0].Message, "f") {
+		t.Errorf("expected the finding to name the function, got %q", findings[0].Message)
+	}
+}
+
+func TestLintUnreachableCodeIsSilentWithoutDeadBlocks(t *testing.T) {
+	fns := []*ast.FunctionDecl{{Name: "f", Body: nil}}
+	if findings := lintUnreachableCode(fns); len(findings) != 0 {
+		t.Errorf("expected no findings, got %v", findings)
+	}
+}
+
+func TestRunVetRejectsAMissingFile(t *testing.T) {
+	err := runVet([]string{"does-not-exist.fer"})
+	if err == nil || !strings.Contains(err.Error(), "reading does-not-exist.fer") {
+		t.Errorf("expected a missing-file error, got %v", err)
+	}
+}
+
+func TestRunVetRejectsUnknownDiagnosticsFormat(t *testing.T) {
+	err := runVet([]string{"-diagnostics-format", "xml", "prog.fer"})
+	if err == nil || !strings.Contains(err.Error(), "-diagnostics-format must be text, json, ndjson or sarif") {
+		t.Errorf("expected an unknown -diagnostics-format to be rejected, got %v", err)
+	}
+}
+
+func TestRunVetRejectsExtraArgs(t *testing.T) {
+	err := runVet([]string{"a", "b"})
+	if err == nil || !strings.Contains(err.Error(), "usage: ferret vet") {
+		t.Errorf("expected extra args to be rejected, got %v", err)
+	}
+}
+
+func TestRunVetReportsNothingForAModuleWithNoDeclarations(t *testing.T) {
+	dir := t.TempDir()
+	input := dir + "/prog.fer"
+	if err := os.WriteFile(input, []byte(""), 0o644); err != nil {
+		t.Fatalf("writing fixture source: %v", err)
+	}
+
+	if err := runVet([]string{input}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
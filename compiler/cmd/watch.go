@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// watchPollInterval is how often watch checks the input file's mtime.
+// There's no fsnotify dependency in go.mod (this repo has none at all —
+// see go.mod), and no network access here to add one, so watch polls
+// instead of subscribing to filesystem events.
+const watchPollInterval = 300 * time.Millisecond
+
+// watch runs rebuild once immediately, then again every time path's mtime
+// advances, until ctx is cancelled. It reports each rebuild's outcome and
+// timing to out, unless level is verbosityQuiet, in which case only a
+// failure is worth saying anything about.
+//
+// There's also no module dependency graph to recompile only a changed
+// module's dependents against (see the import-graph TODO in build.go) — a
+// build today has exactly one input file, so every rebuild just reruns
+// build on that file.
+func watch(ctx context.Context, path string, rebuild func() error, out io.Writer, level verbosity) error {
+	last, err := mtime(path)
+	if err != nil {
+		return err
+	}
+
+	runAndReport := func() {
+		start := time.Now()
+		err := rebuild()
+		elapsed := time.Since(start)
+		if err != nil {
+			fmt.Fprintf(out, "rebuild failed in %s: %v\n", elapsed, err)
+			return
+		}
+		if level > verbosityQuiet {
+			fmt.Fprintf(out, "rebuilt in %s\n", elapsed)
+		}
+	}
+
+	runAndReport()
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m, err := mtime(path)
+			if err != nil {
+				// The file may be momentarily missing mid-save; wait for
+				// the next tick instead of giving up on the whole watch.
+				continue
+			}
+			if m.After(last) {
+				last = m
+				runAndReport()
+			}
+		}
+	}
+}
+
+// mtime returns path's last-modified time.
+func mtime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("ferret: watch: %w", err)
+	}
+	return info.ModTime(), nil
+}
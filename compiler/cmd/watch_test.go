@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWatchRebuildsOnceImmediatelyAndStopsOnCancel(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/prog.fer"
+	if err := os.WriteFile(path, []byte("a"), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	var runs int
+	ctx, cancel := context.WithCancel(context.Background())
+	var out bytes.Buffer
+
+	done := make(chan error, 1)
+	go func() {
+		done <- watch(ctx, path, func() error {
+			runs++
+			return nil
+		}, &out, verbosityNormal)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	if runs != 1 {
+		t.Errorf("expected exactly one rebuild with no file changes, got %d", runs)
+	}
+}
+
+func TestWatchRebuildsWhenTheFileChanges(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/prog.fer"
+	if err := os.WriteFile(path, []byte("a"), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	runs := make(chan struct{}, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	var out bytes.Buffer
+
+	done := make(chan error, 1)
+	go func() {
+		done <- watch(ctx, path, func() error {
+			runs <- struct{}{}
+			return nil
+		}, &out, verbosityNormal)
+	}()
+
+	select {
+	case <-runs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected an immediate rebuild")
+	}
+
+	future := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("touching fixture: %v", err)
+	}
+
+	select {
+	case <-runs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a rebuild after the file changed")
+	}
+
+	cancel()
+	<-done
+}
+
+func TestWatchReturnsAnErrorForAMissingFile(t *testing.T) {
+	if err := watch(context.Background(), "does-not-exist.fer", func() error { return nil }, &bytes.Buffer{}, verbosityNormal); err == nil {
+		t.Error("expected an error for a missing input file")
+	}
+}
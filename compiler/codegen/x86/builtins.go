@@ -0,0 +1,39 @@
+package x86
+
+import (
+	"fmt"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+// generateBuiltinCall lowers calls to the handful of functions the backend
+// recognizes by name rather than by looking them up as user-defined
+// functions. It reports whether call was a builtin; generateCall falls
+// back to a regular `call` instruction when it returns false.
+//
+// There is no frontend type information attached to expressions yet, so
+// both builtins treat their argument as a signed 64-bit integer; a real
+// string/bool-aware print is follow-up work once the type checker feeds
+// codegen.
+func (g *Generator) generateBuiltinCall(call *ast.FunctionCallExpr) bool {
+	var newline int64
+	switch call.Callee {
+	case "print":
+		newline = 0
+	case "println":
+		newline = 1
+	default:
+		return false
+	}
+
+	if len(call.Args) != 1 {
+		panic(fmt.Sprintf("x86: %s expects exactly one argument, got %d", call.Callee, len(call.Args)))
+	}
+
+	g.callRuntime("rt_print_int")
+	g.generateExpr(call.Args[0])
+	g.emit("    mov rdi, rax")
+	g.emit("    mov rsi, %d", newline)
+	g.callAligned("rt_print_int")
+	return true
+}
@@ -0,0 +1,72 @@
+package x86
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+func TestGeneratePrintCallsRuntimeWithoutNewline(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "main",
+		Body: []ast.Stmt{
+			&ast.ExprStmt{X: &ast.FunctionCallExpr{
+				Callee: "print",
+				Args:   []ast.Expr{&ast.IntLiteral{Value: 7}},
+			}},
+			&ast.ReturnStmt{},
+		},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if !strings.Contains(out, "call rt_print_int") {
+		t.Errorf("expected a call to rt_print_int, got:\n%s", out)
+	}
+	if !strings.Contains(out, "mov rsi, 0") {
+		t.Errorf("expected print to pass newline=0, got:\n%s", out)
+	}
+	if !strings.Contains(out, "extern rt_print_int") {
+		t.Errorf("expected rt_print_int to be declared extern, got:\n%s", out)
+	}
+}
+
+func TestGeneratePrintlnPassesNewlineFlag(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "main",
+		Body: []ast.Stmt{
+			&ast.ExprStmt{X: &ast.FunctionCallExpr{
+				Callee: "println",
+				Args:   []ast.Expr{&ast.IntLiteral{Value: 7}},
+			}},
+			&ast.ReturnStmt{},
+		},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if !strings.Contains(out, "mov rsi, 1") {
+		t.Errorf("expected println to pass newline=1, got:\n%s", out)
+	}
+}
+
+func TestGeneratePrintRejectsWrongArity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for print called with no arguments")
+		}
+	}()
+
+	fn := &ast.FunctionDecl{
+		Name: "main",
+		Body: []ast.Stmt{
+			&ast.ExprStmt{X: &ast.FunctionCallExpr{Callee: "print"}},
+			&ast.ReturnStmt{},
+		},
+	}
+
+	NewGenerator().Generate("m", []*ast.FunctionDecl{fn}, nil)
+}
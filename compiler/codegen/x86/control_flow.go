@@ -0,0 +1,140 @@
+package x86
+
+import (
+	"fmt"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+// generateCond evaluates cond and jumps to falseLabel when it is false. It
+// lowers && and || with short-circuit semantics instead of always computing
+// both operands.
+func (g *Generator) generateCond(cond ast.Expr, falseLabel string) {
+	bin, ok := cond.(*ast.BinaryExpr)
+	if !ok {
+		g.generateExpr(cond)
+		g.emit("    cmp rax, 0")
+		g.emit("    je %s", falseLabel)
+		return
+	}
+
+	switch bin.Op {
+	case ast.OpLAnd:
+		// Either side false => whole expression false, short-circuit.
+		g.generateCond(bin.Left, falseLabel)
+		g.generateCond(bin.Right, falseLabel)
+	case ast.OpLOr:
+		// Left true => skip straight past the right side.
+		trueLabel := g.getNextLabel()
+		g.generateCondTrue(bin.Left, trueLabel)
+		g.generateCond(bin.Right, falseLabel)
+		g.emit("%s:", trueLabel)
+	case ast.OpEq, ast.OpLt:
+		g.generateExpr(bin.Left)
+		g.pushReg("rax")
+		g.generateExpr(bin.Right)
+		g.emit("    mov rbx, rax")
+		g.popReg("rax")
+		g.emit("    cmp rax, rbx")
+		g.emit("    %s %s", jumpOnFalse(bin.Op), falseLabel)
+	default:
+		g.generateExpr(cond)
+		g.emit("    cmp rax, 0")
+		g.emit("    je %s", falseLabel)
+	}
+}
+
+// generateCondTrue jumps to trueLabel when cond is true, falling through
+// otherwise. Used to lower the left-hand side of ||.
+func (g *Generator) generateCondTrue(cond ast.Expr, trueLabel string) {
+	skip := g.getNextLabel()
+	g.generateCond(cond, skip)
+	g.emit("    jmp %s", trueLabel)
+	g.emit("%s:", skip)
+}
+
+// generateBinary evaluates a BinaryExpr used as a value (as opposed to a
+// branch condition), leaving the result in rax.
+func (g *Generator) generateBinary(bin *ast.BinaryExpr) {
+	switch bin.Op {
+	case ast.OpAdd, ast.OpSub:
+		// The register-allocated path packs a chain into fewer, reordered
+		// instructions, which is exactly what makes it harder to step
+		// through; it only kicks in once optimizations are requested, so
+		// -O0 output stays a straight line from the source expression.
+		if g.Options.OptLevel > 0 && g.tryGenerateBinaryViaRegalloc(bin) {
+			return
+		}
+		g.generateExpr(bin.Left)
+		g.pushReg("rax")
+		g.generateExpr(bin.Right)
+		g.emit("    mov rbx, rax")
+		g.popReg("rax")
+		if bin.Op == ast.OpAdd {
+			g.emit("    add rax, rbx")
+		} else {
+			g.emit("    sub rax, rbx")
+		}
+	case ast.OpMul:
+		g.generateExpr(bin.Left)
+		g.pushReg("rax")
+		g.generateExpr(bin.Right)
+		g.emit("    mov rbx, rax")
+		g.popReg("rax")
+		g.emit("    imul rax, rbx")
+	case ast.OpDiv:
+		g.generateExpr(bin.Left)
+		g.pushReg("rax")
+		g.generateExpr(bin.Right)
+		g.emit("    mov rbx, rax")
+		g.popReg("rax")
+		g.generateDivByZeroTrap()
+		g.emit("    cqo")
+		g.emit("    idiv rbx")
+	case ast.OpEq, ast.OpLt, ast.OpLAnd, ast.OpLOr:
+		falseLabel := g.getNextLabel()
+		endLabel := g.getNextLabel()
+		g.generateCond(bin, falseLabel)
+		g.emit("    mov rax, 1")
+		g.emit("    jmp %s", endLabel)
+		g.emit("%s:", falseLabel)
+		g.emit("    mov rax, 0")
+		g.emit("%s:", endLabel)
+	default:
+		panic(fmt.Sprintf("x86: unhandled binary operator %v", bin.Op))
+	}
+}
+
+// divByZeroMsg is the message rt_panic prints when a division's divisor
+// turns out to be zero at run time.
+const divByZeroMsg = "ferret: division by zero"
+
+// generateDivByZeroTrap checks rbx (the divisor generateBinary is about to
+// idiv by) and calls rt_panic instead of faulting with a SIGFPE when it's
+// zero. Must run after the divisor is loaded into rbx and before idiv.
+func (g *Generator) generateDivByZeroTrap() {
+	okLabel := g.getNextLabel()
+	label, length := g.addStringConstant(divByZeroMsg)
+	g.callRuntime("rt_panic")
+
+	g.emit("    cmp rbx, 0")
+	g.emit("    jne %s", okLabel)
+	g.emit("    lea rdi, [%s]", label)
+	g.emit("    mov rsi, %d", length)
+	g.callAligned("rt_panic")
+	g.emit("%s:", okLabel)
+}
+
+// jumpOnFalse returns the conditional jump mnemonic that branches when the
+// comparison for op is false, given operands already placed in rax/rbx via
+// `cmp rax, rbx`.
+func jumpOnFalse(op ast.BinaryOp) string {
+	switch op {
+	case ast.OpEq:
+		return "jne"
+	case ast.OpLt:
+		return "jge"
+	default:
+		panic(fmt.Sprintf("x86: %v is not a comparison operator", op))
+	}
+}
@@ -0,0 +1,131 @@
+package x86
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+func TestGenerateIfElseEmitsJumps(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name:   "max",
+		Params: []ast.Param{{Name: "a", Type: "i32"}, {Name: "b", Type: "i32"}},
+		Body: []ast.Stmt{
+			&ast.IfStmt{
+				Cond: &ast.BinaryExpr{Op: ast.OpLt, Left: &ast.Ident{Name: "a"}, Right: &ast.Ident{Name: "b"}},
+				Then: []ast.Stmt{&ast.ReturnStmt{Value: &ast.Ident{Name: "b"}}},
+				Else: []ast.Stmt{&ast.ReturnStmt{Value: &ast.Ident{Name: "a"}}},
+			},
+		},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if !strings.Contains(out, "jge") {
+		t.Errorf("expected a jge for the < comparison, got:\n%s", out)
+	}
+	// then/else both return directly; the merge block is currently still
+	// emitted even though it's unreachable (removing dead blocks like this
+	// is a follow-up optimization pass, not this change's concern).
+	if strings.Count(out, "    ret") != 3 {
+		t.Errorf("expected a ret in each of then, else and the trailing merge block, got:\n%s", out)
+	}
+}
+
+func TestGenerateMultiplication(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "f",
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{Value: &ast.BinaryExpr{
+				Op: ast.OpMul, Left: &ast.Ident{Name: "a"}, Right: &ast.IntLiteral{Value: 2},
+			}},
+		},
+		Params: []ast.Param{{Name: "a", Type: "i32"}},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if !strings.Contains(out, "imul rax, rbx") {
+		t.Errorf("expected an imul for *, got:\n%s", out)
+	}
+}
+
+func TestGenerateDivisionTrapsOnZeroDivisor(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "f",
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{Value: &ast.BinaryExpr{
+				Op: ast.OpDiv, Left: &ast.Ident{Name: "a"}, Right: &ast.Ident{Name: "b"},
+			}},
+		},
+		Params: []ast.Param{{Name: "a", Type: "i32"}, {Name: "b", Type: "i32"}},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if !strings.Contains(out, "cmp rbx, 0") {
+		t.Errorf("expected a zero-divisor check before idiv, got:\n%s", out)
+	}
+	if !strings.Contains(out, "call rt_panic") {
+		t.Errorf("expected a trap into rt_panic on a zero divisor, got:\n%s", out)
+	}
+	if !strings.Contains(out, "extern rt_panic") {
+		t.Errorf("expected rt_panic to be declared extern, got:\n%s", out)
+	}
+	if !strings.Contains(out, "cqo") || !strings.Contains(out, "idiv rbx") {
+		t.Errorf("expected a sign-extended idiv by rbx, got:\n%s", out)
+	}
+}
+
+func TestGenerateBinaryAlignsNestedCall(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "main",
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{Value: &ast.BinaryExpr{
+				Op:    ast.OpAdd,
+				Left:  &ast.IntLiteral{Value: 1},
+				Right: &ast.FunctionCallExpr{Callee: "helper"},
+			}},
+		},
+	}
+	helper := &ast.ExternFuncDecl{Name: "helper", ReturnType: "i64"}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, []*ast.ExternFuncDecl{helper})
+
+	// The left operand is still pushed when helper is called for the right
+	// operand, so the call site needs an extra 8 bytes of padding to land
+	// back on a 16-byte boundary.
+	if !strings.Contains(out, "sub rsp, 8\n    call helper\n    add rsp, 8") {
+		t.Errorf("expected helper's call site to be padded back to 16-byte alignment, got:\n%s", out)
+	}
+}
+
+func TestGenerateShortCircuitAnd(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "f",
+		Body: []ast.Stmt{
+			&ast.IfStmt{
+				Cond: &ast.BinaryExpr{
+					Op:    ast.OpLAnd,
+					Left:  &ast.IntLiteral{Value: 1},
+					Right: &ast.IntLiteral{Value: 0},
+				},
+				Then: []ast.Stmt{&ast.ReturnStmt{Value: &ast.IntLiteral{Value: 1}}},
+			},
+		},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	// Both operands get their own false-check guarding entry into the
+	// then-branch.
+	if strings.Count(out, "    je ") < 2 {
+		t.Errorf("expected short-circuit checks for both && operands, got:\n%s", out)
+	}
+}
@@ -0,0 +1,55 @@
+package x86
+
+import (
+	"fmt"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+	"github.com/itsfuad/ferret-compiler/compiler/target"
+)
+
+// ValidateEntryPoint checks that fns declares the `fn main()` every
+// executable module needs: a function named "main" taking no parameters.
+// There's no declaration-collection pass to run this during yet (see the
+// TODO in compiler/cmd/build.go's build) — callers assembling a full
+// program call it themselves before Generate.
+func ValidateEntryPoint(fns []*ast.FunctionDecl) error {
+	for _, fn := range fns {
+		if fn.Name != "main" {
+			continue
+		}
+		if len(fn.Params) != 0 {
+			return fmt.Errorf("x86: fn main() must take no parameters, got %d", len(fn.Params))
+		}
+		return nil
+	}
+	return fmt.Errorf("x86: entry module has no fn main()")
+}
+
+// usesMainTrampoline reports whether main is generated as an ordinary
+// mangled function, called from a separate entry-point trampoline, rather
+// than directly under the platform entry symbol.
+//
+// This only applies on Linux: mainCRTStartup and _main on the other
+// targets are CRT hooks that a real C runtime calls into and then handles
+// the exit code for, so generating main's body directly under that symbol
+// and ret-ing with the return value in eax is already correct there. _start
+// has no such CRT underneath it — it's the raw symbol the kernel jumps to
+// with no return address on the stack, so a bare `ret` would fault instead
+// of exiting.
+func (g *Generator) usesMainTrampoline() bool {
+	return g.Options.EntryModule && g.Options.Target.OS == target.Linux
+}
+
+// generateEntryTrampoline emits the real process entry point for Linux: it
+// calls the mangled main and converts its return value into the process
+// exit code via sys_exit_group, instead of executing main's statements
+// directly under _start the way generateFunctions used to.
+func (g *Generator) generateEntryTrampoline() {
+	entry := g.Options.Target.EntrySymbol()
+	g.emit("%s:", entry)
+	g.emit("    call %s", mangleSymbol(g.moduleName, "main"))
+	g.emit("    mov edi, eax")
+	g.emit("    mov eax, 231") // sys_exit_group
+	g.emit("    syscall")
+	g.emit("")
+}
@@ -0,0 +1,64 @@
+package x86
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+func TestValidateEntryPointRequiresMain(t *testing.T) {
+	fns := []*ast.FunctionDecl{{Name: "helper"}}
+	if err := ValidateEntryPoint(fns); err == nil {
+		t.Error("expected an error when no fn main() is declared")
+	}
+}
+
+func TestValidateEntryPointRejectsParams(t *testing.T) {
+	fns := []*ast.FunctionDecl{{Name: "main", Params: []ast.Param{{Name: "argc", Type: "i32"}}}}
+	if err := ValidateEntryPoint(fns); err == nil {
+		t.Error("expected an error when main takes parameters")
+	}
+}
+
+func TestValidateEntryPointAcceptsZeroParamMain(t *testing.T) {
+	fns := []*ast.FunctionDecl{{Name: "main"}}
+	if err := ValidateEntryPoint(fns); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGenerateEntryTrampolineCallsMangledMain(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "main",
+		Body: []ast.Stmt{&ast.ReturnStmt{Value: &ast.IntLiteral{Value: 5}}},
+	}
+
+	g := NewGeneratorWithOptions(GeneratorOptions{EntryModule: true})
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	mangled := mangleSymbol("m", "main")
+	if !strings.Contains(out, "call "+mangled) {
+		t.Errorf("expected _start to call the mangled main %q, got:\n%s", mangled, out)
+	}
+	if !strings.Contains(out, "mov eax, 231") || !strings.Contains(out, "syscall") {
+		t.Errorf("expected _start to exit via sys_exit_group, got:\n%s", out)
+	}
+	if strings.Contains(out, mangled+":\n    push rbp\n_start:") {
+		t.Errorf("expected main's body and the trampoline to be separate labels, got:\n%s", out)
+	}
+}
+
+func TestGenerateWithoutEntryModuleKeepsMainUnderEntrySymbol(t *testing.T) {
+	fn := &ast.FunctionDecl{Name: "main", Body: []ast.Stmt{&ast.ReturnStmt{}}}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if !strings.Contains(out, "_start:") {
+		t.Errorf("expected main to still be emitted directly under _start without EntryModule, got:\n%s", out)
+	}
+	if strings.Contains(out, "call "+mangleSymbol("m", "main")) {
+		t.Errorf("expected no call to a mangled main without EntryModule, got:\n%s", out)
+	}
+}
@@ -0,0 +1,59 @@
+package x86
+
+import (
+	"fmt"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+// generateFloatExpr evaluates a floating-point expression, leaving the
+// result in xmm0.
+func (g *Generator) generateFloatExpr(e ast.Expr) {
+	switch v := e.(type) {
+	case *ast.FloatLiteral:
+		label := g.addFloatConstant(v.Value)
+		g.emit("    movsd xmm0, [%s]", label)
+	case *ast.FloatBinaryExpr:
+		g.generateFloatBinary(v)
+	case *ast.CastExpr:
+		g.generateCast(v)
+	default:
+		panic(fmt.Sprintf("x86: unhandled float expression %T", v))
+	}
+}
+
+// generateFloatBinary evaluates a FloatBinaryExpr, leaving the result in
+// xmm0 (or, for a comparison, a 0/1 integer result in rax).
+func (g *Generator) generateFloatBinary(bin *ast.FloatBinaryExpr) {
+	g.generateFloatExpr(bin.Left)
+	g.emit("    sub rsp, 8")
+	g.emit("    movsd [rsp], xmm0")
+	g.generateFloatExpr(bin.Right)
+	g.emit("    movsd xmm1, xmm0")
+	g.emit("    movsd xmm0, [rsp]")
+	g.emit("    add rsp, 8")
+
+	switch bin.Op {
+	case ast.FOpAdd:
+		g.emit("    addsd xmm0, xmm1")
+	case ast.FOpSub:
+		g.emit("    subsd xmm0, xmm1")
+	case ast.FOpLt:
+		g.emit("    ucomisd xmm0, xmm1")
+		g.emit("    setb al")
+		g.emit("    movzx rax, al")
+	default:
+		panic(fmt.Sprintf("x86: unhandled float operator %v", bin.Op))
+	}
+}
+
+// generateCast lowers an int<->float conversion.
+func (g *Generator) generateCast(c *ast.CastExpr) {
+	if c.ToFloat {
+		g.generateExpr(c.Value)
+		g.emit("    cvtsi2sd xmm0, rax")
+		return
+	}
+	g.generateFloatExpr(c.Value)
+	g.emit("    cvttsd2si rax, xmm0")
+}
@@ -0,0 +1,43 @@
+package x86
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+func TestGenerateFloatLiteralUsesDataSection(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "f",
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{Value: &ast.FloatLiteral{Value: 3.5}},
+		},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if !strings.Contains(out, "section .data") {
+		t.Errorf("expected a .data section for the float constant, got:\n%s", out)
+	}
+	if !strings.Contains(out, "movsd xmm0, [.LC0]") {
+		t.Errorf("expected the literal to be loaded via movsd, got:\n%s", out)
+	}
+}
+
+func TestGenerateCastIntToFloat(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "f",
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{Value: &ast.CastExpr{Value: &ast.IntLiteral{Value: 1}, ToFloat: true}},
+		},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if !strings.Contains(out, "cvtsi2sd xmm0, rax") {
+		t.Errorf("expected an int->float conversion, got:\n%s", out)
+	}
+}
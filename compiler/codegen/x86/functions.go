@@ -0,0 +1,198 @@
+package x86
+
+import (
+	"fmt"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+	"github.com/itsfuad/ferret-compiler/compiler/ir"
+	"github.com/itsfuad/ferret-compiler/compiler/target"
+)
+
+// stackPageSize is the guard-page granularity generateStackProbe touches a
+// large frame in.
+const stackPageSize = 4096
+
+// sysvIntArgRegs are the System V AMD64 integer/pointer argument registers,
+// in order.
+var sysvIntArgRegs = []string{"rdi", "rsi", "rdx", "rcx", "r8", "r9"}
+
+// generateFunctions emits one label per function declaration. Every
+// function is mangled (see mangle.go) and marked global so it's callable
+// by its qualified name from another module's object file once linked
+// together, without colliding with an identically named symbol there;
+// "main" is the one exception, emitted under the platform's entry symbol
+// instead since the CRT/loader expects that name verbatim.
+func (g *Generator) generateFunctions(fns []*ast.FunctionDecl) {
+	for _, fn := range fns {
+		g.emit("global %s", g.functionLabel(fn.Name))
+	}
+	if g.usesMainTrampoline() {
+		g.emit("global %s", g.Options.Target.EntrySymbol())
+	}
+	for _, fn := range fns {
+		g.generateFunction(fn)
+	}
+	if g.usesMainTrampoline() {
+		g.generateEntryTrampoline()
+	}
+}
+
+// functionLabel returns the assembly label for a function defined in this
+// module: the mangled name, or the platform entry symbol for "main" — the
+// latter only when main isn't already reached through generateEntryTrampoline
+// (see usesMainTrampoline).
+func (g *Generator) functionLabel(name string) string {
+	if name == "main" && !g.usesMainTrampoline() {
+		return g.Options.Target.EntrySymbol()
+	}
+	return mangleSymbol(g.moduleName, name)
+}
+
+// generateFunction lowers a single function to assembly using the System V
+// AMD64 calling convention: the first six integer/pointer arguments arrive
+// in registers, locals and spilled parameters live in the stack frame
+// below rbp. The function body itself is generated from the target-
+// independent IR (see compiler/ir), not walked directly off the AST.
+func (g *Generator) generateFunction(fn *ast.FunctionDecl) {
+	g.locals = make(map[string]int)
+	g.stackSize = 0
+
+	lowered := ir.Lower(fn)
+	if g.Options.DumpIR {
+		g.irDump += fmt.Sprintf("; --- IR for %s before optimization ---\n%s", fn.Name, ir.Dump(lowered))
+	}
+	if g.Options.OptLevel > 0 {
+		ir.Optimize(lowered)
+	}
+	if g.Options.OptLevel > 1 {
+		ir.Peephole(lowered)
+	}
+	if g.Options.DumpIR {
+		g.irDump += fmt.Sprintf("; --- IR for %s after optimization ---\n%s", fn.Name, ir.Dump(lowered))
+	}
+
+	g.emit("%s:", g.functionLabel(fn.Name))
+	g.emit("    push rbp")
+	g.emit("    mov rbp, rsp")
+
+	// Reserve a stack slot for every parameter so the body can reference
+	// them by name like any other local.
+	for i, p := range fn.Params {
+		offset := g.allocSlot(p.Name)
+		if i < len(sysvIntArgRegs) {
+			g.emit("    mov [rbp-%d], %s", offset, sysvIntArgRegs[i])
+		} else {
+			// Parameters past the sixth arrive on the caller's stack,
+			// above the return address pushed by `call`.
+			callerOffset := 16 + (i-len(sysvIntArgRegs))*8
+			g.emit("    mov rax, [rbp+%d]", callerOffset)
+			g.emit("    mov [rbp-%d], rax", offset)
+		}
+	}
+
+	// emitIRFunction may grow the frame further (Assign instructions), so
+	// the frame is sized from the IR up front before the real prologue's
+	// `sub rsp` is emitted.
+	frameSize := alignTo16(len(fn.Params)*8 + countAssigns(lowered)*8)
+	if g.Options.Target.OS == target.Windows && frameSize > stackPageSize {
+		g.generateStackProbe(frameSize)
+	} else if frameSize > 0 {
+		g.emit("    sub rsp, %d", frameSize)
+	}
+
+	g.emitIRFunction(lowered)
+
+	g.emit("")
+}
+
+// allocSlot reserves the next 8-byte stack slot for name and returns its
+// offset from rbp.
+func (g *Generator) allocSlot(name string) int {
+	g.stackSize += 8
+	g.locals[name] = g.stackSize
+	return g.stackSize
+}
+
+func countAssigns(fn *ir.Function) int {
+	n := 0
+	for _, blk := range fn.Blocks {
+		for _, in := range blk.Instrs {
+			if in.Kind == ir.InstrAssign {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func alignTo16(n int) int {
+	return (n + 15) &^ 15
+}
+
+// generateStackProbe commits a frame larger than one page by touching it a
+// page at a time from the top down, instead of a single `sub rsp` that
+// would jump straight past the guard page below the stack. Windows grows
+// the stack by faulting on that guard page one page below the lowest
+// committed address; skip a page and the fault never happens, and the
+// thread runs off the end of its reserved stack instead of growing it.
+// POSIX targets don't need this: the kernel grows the stack on any write
+// to an unmapped page below it, committed pages or not.
+func (g *Generator) generateStackProbe(frameSize int) {
+	loop := g.getNextLabel()
+	g.emit("    mov rax, %d", frameSize)
+	g.emit("%s:", loop)
+	g.emit("    sub rsp, %d", stackPageSize)
+	g.emit("    or qword [rsp], 0")
+	g.emit("    sub rax, %d", stackPageSize)
+	g.emit("    cmp rax, %d", stackPageSize)
+	g.emit("    jg %s", loop)
+	g.emit("    sub rsp, rax")
+}
+
+// generateExpr evaluates e, leaving the result in rax.
+func (g *Generator) generateExpr(e ast.Expr) {
+	switch v := e.(type) {
+	case *ast.IntLiteral:
+		g.emit("    mov rax, %d", v.Value)
+	case *ast.Ident:
+		offset, ok := g.locals[v.Name]
+		if !ok {
+			panic(fmt.Sprintf("x86: unknown identifier %q", v.Name))
+		}
+		g.emit("    mov rax, [rbp-%d]", offset)
+	case *ast.FunctionCallExpr:
+		g.generateCall(v)
+	case *ast.BinaryExpr:
+		g.generateBinary(v)
+	case *ast.FloatLiteral, *ast.FloatBinaryExpr, *ast.CastExpr:
+		g.generateFloatExpr(e)
+	default:
+		panic(fmt.Sprintf("x86: unhandled expression %T", v))
+	}
+}
+
+// generateCall marshals Args into the System V argument registers and
+// issues the call. Arguments are evaluated left to right and moved into
+// their target register immediately, which is correct as long as no
+// argument expression itself clobbers an earlier argument's register —
+// true for the literal/identifier expressions the frontend currently
+// produces.
+func (g *Generator) generateCall(call *ast.FunctionCallExpr) {
+	if g.generateBuiltinCall(call) {
+		return
+	}
+
+	for i, arg := range call.Args {
+		g.generateExpr(arg)
+		if i < len(sysvIntArgRegs) {
+			g.emit("    mov %s, rax", sysvIntArgRegs[i])
+		} else {
+			g.pushReg("rax")
+		}
+	}
+	callee := call.Callee
+	if g.localFns[callee] {
+		callee = mangleSymbol(g.moduleName, callee)
+	}
+	g.callAligned(callee)
+}
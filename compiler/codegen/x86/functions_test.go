@@ -0,0 +1,132 @@
+package x86
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+	"github.com/itsfuad/ferret-compiler/compiler/target"
+)
+
+func TestGenerateFunctionPrologueEpilogue(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name:   "add",
+		Params: []ast.Param{{Name: "a", Type: "i32"}, {Name: "b", Type: "i32"}},
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{Value: &ast.Ident{Name: "a"}},
+		},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if !strings.Contains(out, "add:") {
+		t.Errorf("expected a label for function add, got:\n%s", out)
+	}
+	if !strings.Contains(out, "push rbp") || !strings.Contains(out, "pop rbp") {
+		t.Errorf("expected prologue/epilogue, got:\n%s", out)
+	}
+	if !strings.Contains(out, "mov [rbp-8], rdi") {
+		t.Errorf("expected first parameter stored from rdi, got:\n%s", out)
+	}
+}
+
+func TestGenerateFunctionProbesStackOnWindowsPastOnePage(t *testing.T) {
+	// 520 params needs a 4160-byte frame, just over one 4096-byte page.
+	params := make([]ast.Param, 520)
+	for i := range params {
+		params[i] = ast.Param{Name: "p" + string(rune('a'+i%26)) + string(rune('0'+i/26%10)), Type: "i32"}
+	}
+	fn := &ast.FunctionDecl{Name: "big", Params: params, Body: []ast.Stmt{&ast.ReturnStmt{}}}
+
+	windows := NewGeneratorWithOptions(GeneratorOptions{Target: target.Target{OS: target.Windows}})
+	out := windows.Generate("m", []*ast.FunctionDecl{fn}, nil)
+	if !strings.Contains(out, "or qword [rsp], 0") {
+		t.Errorf("expected a page-by-page stack probe for a frame over one page on Windows, got:\n%s", out)
+	}
+
+	linux := NewGenerator()
+	out = linux.Generate("m", []*ast.FunctionDecl{fn}, nil)
+	if strings.Contains(out, "or qword [rsp], 0") {
+		t.Errorf("expected no stack probe on Linux, which grows the stack on any write below it, got:\n%s", out)
+	}
+}
+
+func TestGenerateCallMarshalsArgsInOrder(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "main",
+		Body: []ast.Stmt{
+			&ast.ExprStmt{X: &ast.FunctionCallExpr{
+				Callee: "log",
+				Args:   []ast.Expr{&ast.IntLiteral{Value: 42}},
+			}},
+			&ast.ReturnStmt{},
+		},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if !strings.Contains(out, "mov rax, 42") || !strings.Contains(out, "mov rdi, rax") || !strings.Contains(out, "call log") {
+		t.Errorf("expected argument marshalled into rdi before call, got:\n%s", out)
+	}
+}
+
+func TestGenerateCallToLocalFunctionUsesMangledLabel(t *testing.T) {
+	helper := &ast.FunctionDecl{
+		Name: "helper",
+		Body: []ast.Stmt{&ast.ReturnStmt{Value: &ast.IntLiteral{Value: 1}}},
+	}
+	main := &ast.FunctionDecl{
+		Name: "main",
+		Body: []ast.Stmt{
+			&ast.ExprStmt{X: &ast.FunctionCallExpr{Callee: "helper"}},
+			&ast.ReturnStmt{},
+		},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{main, helper}, nil)
+
+	want := mangleSymbol("m", "helper")
+	if !strings.Contains(out, "global "+want) {
+		t.Errorf("expected helper to be declared global under its mangled name %q, got:\n%s", want, out)
+	}
+	if !strings.Contains(out, want+":") {
+		t.Errorf("expected a label for the mangled name %q, got:\n%s", want, out)
+	}
+	if !strings.Contains(out, "call "+want) {
+		t.Errorf("expected the call site to use the mangled name %q, got:\n%s", want, out)
+	}
+}
+
+func TestGenerateExternDeclaresSymbolWithNoBody(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "main",
+		Body: []ast.Stmt{
+			&ast.ExprStmt{X: &ast.FunctionCallExpr{
+				Callee: "write",
+				Args:   []ast.Expr{&ast.IntLiteral{Value: 1}},
+			}},
+			&ast.ReturnStmt{},
+		},
+	}
+	write := &ast.ExternFuncDecl{
+		Name:       "write",
+		Params:     []ast.Param{{Name: "fd", Type: "i32"}, {Name: "buf", Type: "str"}, {Name: "len", Type: "i64"}},
+		ReturnType: "i64",
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, []*ast.ExternFuncDecl{write})
+
+	if !strings.Contains(out, "extern write") {
+		t.Errorf("expected extern declaration for write, got:\n%s", out)
+	}
+	if !strings.Contains(out, "call write") {
+		t.Errorf("expected a plain call to write, got:\n%s", out)
+	}
+	if strings.Contains(out, "write:") {
+		t.Errorf("extern declarations must not get a function label, got:\n%s", out)
+	}
+}
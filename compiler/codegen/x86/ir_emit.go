@@ -0,0 +1,53 @@
+package x86
+
+import (
+	"fmt"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ir"
+)
+
+// emitIRFunction emits the body of fn, whose prologue (and the `sub rsp`
+// for its frame) has already been written by generateFunction. Blocks are
+// laid out in the order Lower produced them, so a TermCondJump's true
+// branch is always the block immediately following — only the false
+// target needs an explicit label reference.
+func (g *Generator) emitIRFunction(fn *ir.Function) {
+	for i, blk := range fn.Blocks {
+		if i > 0 {
+			g.emit("%s:", blk.Label)
+		}
+
+		for _, in := range blk.Instrs {
+			switch in.Kind {
+			case ir.InstrAssign:
+				g.generateExpr(in.Value)
+				offset := g.allocSlot(in.Name)
+				g.emit("    mov [rbp-%d], rax", offset)
+			case ir.InstrEval:
+				g.generateExpr(in.Value)
+			default:
+				panic(fmt.Sprintf("x86: unhandled IR instruction kind %v", in.Kind))
+			}
+		}
+
+		g.emitTerminator(blk.Term)
+	}
+}
+
+func (g *Generator) emitTerminator(term ir.Terminator) {
+	switch term.Kind {
+	case ir.TermReturn:
+		if term.Value != nil {
+			g.generateExpr(term.Value)
+		}
+		g.emit("    mov rsp, rbp")
+		g.emit("    pop rbp")
+		g.emit("    ret")
+	case ir.TermJump:
+		g.emit("    jmp %s", term.Target)
+	case ir.TermCondJump:
+		g.generateCond(term.Cond, term.FalseTarget)
+	default:
+		panic(fmt.Sprintf("x86: unhandled IR terminator kind %v", term.Kind))
+	}
+}
@@ -0,0 +1,43 @@
+package x86
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mangleSymbol encodes modulePath and name into a single assembly label
+// that can't collide with another module's symbol of the same name. Both
+// components are length-prefixed the way the Itanium C++ ABI mangles
+// qualified names: reading a decimal length then consuming exactly that
+// many identifier characters parses unambiguously, which is also what
+// keeps concatenation collision-free (a module "ab" with symbol "c" can
+// never produce the same label as module "a" with symbol "bc").
+//
+// Later, generic instantiations will extend this with a length-prefixed
+// segment per type argument, following the same scheme.
+func mangleSymbol(modulePath, name string) string {
+	mod := sanitizeIdent(modulePath)
+	sym := sanitizeIdent(name)
+	return fmt.Sprintf("_FR%d%s%d%s", len(mod), mod, len(sym), sym)
+}
+
+// sanitizeIdent rewrites s into a valid, non-empty assembly identifier:
+// anything outside [A-Za-z0-9_] becomes '_', and a leading digit is
+// prefixed with '_' so mangleSymbol's length prefixes stay unambiguous
+// (an identifier can never itself start with a digit).
+func sanitizeIdent(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	out := b.String()
+	if out == "" || (out[0] >= '0' && out[0] <= '9') {
+		out = "_" + out
+	}
+	return out
+}
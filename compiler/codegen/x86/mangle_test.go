@@ -0,0 +1,34 @@
+package x86
+
+import "testing"
+
+func TestMangleSymbolIsDeterministicAndModuleQualified(t *testing.T) {
+	a := mangleSymbol("mymod", "add")
+	b := mangleSymbol("mymod", "add")
+	if a != b {
+		t.Errorf("expected mangleSymbol to be deterministic, got %q and %q", a, b)
+	}
+
+	other := mangleSymbol("othermod", "add")
+	if a == other {
+		t.Errorf("expected different modules to mangle %q differently, both got %q", "add", a)
+	}
+}
+
+func TestMangleSymbolAvoidsConcatenationCollisions(t *testing.T) {
+	// Naive "module.name" concatenation would make these collide.
+	a := mangleSymbol("ab", "c")
+	b := mangleSymbol("a", "bc")
+	if a == b {
+		t.Errorf("expected mangleSymbol(%q,%q) and mangleSymbol(%q,%q) to differ, both got %q", "ab", "c", "a", "bc", a)
+	}
+}
+
+func TestSanitizeIdentEscapesLeadingDigitAndInvalidChars(t *testing.T) {
+	if got := sanitizeIdent("9lives"); got != "_9lives" {
+		t.Errorf("sanitizeIdent(%q) = %q, want %q", "9lives", got, "_9lives")
+	}
+	if got := sanitizeIdent("a/b.fer"); got != "a_b_fer" {
+		t.Errorf("sanitizeIdent(%q) = %q, want %q", "a/b.fer", got, "a_b_fer")
+	}
+}
@@ -0,0 +1,236 @@
+package x86
+
+import "github.com/itsfuad/ferret-compiler/compiler/ast"
+
+// This file introduces a small virtual-register IR and a linear-scan
+// allocator for it, used to lower simple arithmetic chains without the
+// push/pop stack traffic generateBinary falls back to. It intentionally
+// covers only flat add/sub/literal/identifier expressions for now — calls,
+// comparisons and floats still go through the existing path.
+
+// vreg identifies a virtual register, numbered in SSA-like definition order.
+type vreg int
+
+// vop is a three-address instruction over virtual registers.
+type vop struct {
+	op       ast.BinaryOp // OpAdd or OpSub; zero value unused for vopConst/vopLoad
+	dst      vreg
+	isConst  bool
+	isLoad   bool
+	constVal int64
+	loadName string
+	src1     vreg
+	src2     vreg
+}
+
+// physRegs are the general-purpose registers the linear-scan allocator may
+// assign, in preference order. rax is kept last since call-site lowering
+// still depends on it as the implicit accumulator.
+var physRegs = []string{"rbx", "rcx", "rdx", "rsi", "rdi", "rax"}
+
+// scratchReg is a register linearScanAllocate never assigns, kept free so
+// the emission loop below can route a spilled-to-spilled operand pair
+// through it: x86-64 rejects an instruction with two memory operands, and
+// a dst and src that both outlived the physical registers can both be
+// spilled at once.
+const scratchReg = "r11"
+
+// buildVRegChain lowers a tree of +/- over idents and int literals into a
+// flat list of three-address instructions. It reports ok=false for any
+// expression shape it doesn't understand, so the caller can fall back to
+// the stack-based path.
+func buildVRegChain(e ast.Expr) (instrs []vop, result vreg, ok bool) {
+	next := vreg(0)
+	var walk func(ast.Expr) (vreg, bool)
+	walk = func(e ast.Expr) (vreg, bool) {
+		switch v := e.(type) {
+		case *ast.IntLiteral:
+			r := next
+			next++
+			instrs = append(instrs, vop{dst: r, isConst: true, constVal: v.Value})
+			return r, true
+		case *ast.Ident:
+			r := next
+			next++
+			instrs = append(instrs, vop{dst: r, isLoad: true, loadName: v.Name})
+			return r, true
+		case *ast.BinaryExpr:
+			if v.Op != ast.OpAdd && v.Op != ast.OpSub {
+				return 0, false
+			}
+			l, ok := walk(v.Left)
+			if !ok {
+				return 0, false
+			}
+			r, ok := walk(v.Right)
+			if !ok {
+				return 0, false
+			}
+			d := next
+			next++
+			instrs = append(instrs, vop{op: v.Op, dst: d, src1: l, src2: r})
+			return d, true
+		default:
+			return 0, false
+		}
+	}
+	r, ok := walk(e)
+	return instrs, r, ok
+}
+
+// linearScanAllocate assigns a physical register name to every virtual
+// register in instrs using linear scan over their [def, last-use] live
+// intervals. Virtual registers that outlive the available physical
+// registers are spilled to a fresh stack slot instead.
+func (g *Generator) linearScanAllocate(instrs []vop) map[vreg]string {
+	starts := map[vreg]int{}
+	ends := map[vreg]int{}
+	for i, ins := range instrs {
+		starts[ins.dst] = i
+		ends[ins.dst] = i
+		for _, use := range []vreg{ins.src1, ins.src2} {
+			if e, ok := ends[use]; !ok || i > e {
+				ends[use] = i
+			}
+		}
+	}
+
+	order := make([]vreg, 0, len(starts))
+	for r := range starts {
+		order = append(order, r)
+	}
+	for i := 1; i < len(order); i++ {
+		for j := i; j > 0 && starts[order[j-1]] > starts[order[j]]; j-- {
+			order[j-1], order[j] = order[j], order[j-1]
+		}
+	}
+
+	assignment := map[vreg]string{}
+	active := map[vreg]string{}
+	free := append([]string(nil), physRegs...)
+
+	for _, r := range order {
+		for a, reg := range active {
+			if ends[a] < starts[r] {
+				free = append(free, reg)
+				delete(active, a)
+			}
+		}
+		if len(free) == 0 {
+			// Spill: reuse rax via a stack slot rather than failing.
+			offset := g.allocSlot(spillName(r))
+			assignment[r] = spillOperand(offset)
+			continue
+		}
+		reg := free[len(free)-1]
+		free = free[:len(free)-1]
+		assignment[r] = reg
+		active[r] = reg
+	}
+	return assignment
+}
+
+// tryGenerateBinaryViaRegalloc attempts to lower a flat +/- expression tree
+// through the vreg IR and linear-scan allocator, emitting the result into
+// rax. It returns false if the expression isn't a shape buildVRegChain
+// understands, so the caller can fall back to the stack-based path.
+func (g *Generator) tryGenerateBinaryViaRegalloc(e ast.Expr) bool {
+	instrs, result, ok := buildVRegChain(e)
+	if !ok || len(instrs) < 2 {
+		// Not worth allocating registers for a single literal/identifier.
+		return false
+	}
+
+	alloc := g.linearScanAllocate(instrs)
+	for _, ins := range instrs {
+		dst := alloc[ins.dst]
+		switch {
+		case ins.isConst:
+			if isMemOperand(dst) {
+				// A bare immediate-to-memory mov is size-ambiguous to NASM.
+				g.emit("    mov qword %s, %d", dst, ins.constVal)
+			} else {
+				g.emit("    mov %s, %d", dst, ins.constVal)
+			}
+		case ins.isLoad:
+			offset, ok := g.locals[ins.loadName]
+			if !ok {
+				panic("x86: unknown identifier " + ins.loadName)
+			}
+			g.emitMove(dst, "[rbp-"+itoa(offset)+"]")
+		default:
+			src1, src2 := alloc[ins.src1], alloc[ins.src2]
+			if dst != src1 {
+				g.emitMove(dst, src1)
+			}
+			if ins.op == ast.OpAdd {
+				g.emitArith("add", dst, src2)
+			} else {
+				g.emitArith("sub", dst, src2)
+			}
+		}
+	}
+	if resultReg := alloc[result]; resultReg != "rax" {
+		g.emit("    mov rax, %s", resultReg)
+	}
+	return true
+}
+
+// isMemOperand reports whether operand is a memory reference like
+// "[rbp-8]" rather than a register name.
+func isMemOperand(operand string) bool {
+	return len(operand) > 0 && operand[0] == '['
+}
+
+// emitMove emits dst = src, routing through scratchReg when both sides are
+// memory operands, since neither mov nor the arithmetic ops below can read
+// two memory operands at once.
+func (g *Generator) emitMove(dst, src string) {
+	if isMemOperand(dst) && isMemOperand(src) {
+		g.emit("    mov %s, %s", scratchReg, src)
+		g.emit("    mov %s, %s", dst, scratchReg)
+		return
+	}
+	g.emit("    mov %s, %s", dst, src)
+}
+
+// emitArith emits `mnemonic dst, src` (add/sub), routing src through
+// scratchReg first when dst and src are both memory operands.
+func (g *Generator) emitArith(mnemonic, dst, src string) {
+	if isMemOperand(dst) && isMemOperand(src) {
+		g.emit("    mov %s, %s", scratchReg, src)
+		g.emit("    %s %s, %s", mnemonic, dst, scratchReg)
+		return
+	}
+	g.emit("    %s %s, %s", mnemonic, dst, src)
+}
+
+func spillName(r vreg) string {
+	return "%spill" + itoa(int(r))
+}
+
+func spillOperand(offset int) string {
+	return "[rbp-" + itoa(offset) + "]"
+}
+
+func itoa(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	var buf [20]byte
+	i := len(buf)
+	for n > 0 {
+		i--
+		buf[i] = byte('0' + n%10)
+		n /= 10
+	}
+	if neg {
+		i--
+		buf[i] = '-'
+	}
+	return string(buf[i:])
+}
@@ -0,0 +1,107 @@
+package x86
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+func TestLinearScanAvoidsStackTrafficForChain(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name:   "sum3",
+		Params: []ast.Param{{Name: "a", Type: "i32"}, {Name: "b", Type: "i32"}, {Name: "c", Type: "i32"}},
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{Value: &ast.BinaryExpr{
+				Op:    ast.OpAdd,
+				Left:  &ast.BinaryExpr{Op: ast.OpAdd, Left: &ast.Ident{Name: "a"}, Right: &ast.Ident{Name: "b"}},
+				Right: &ast.Ident{Name: "c"},
+			}},
+		},
+	}
+
+	g := NewGeneratorWithOptions(GeneratorOptions{OptLevel: 1})
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if strings.Contains(out, "push rax") {
+		t.Errorf("expected the register allocator to avoid push/pop for a flat add chain, got:\n%s", out)
+	}
+}
+
+func TestLinearScanDisabledAtOptLevelZero(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name:   "sum3",
+		Params: []ast.Param{{Name: "a", Type: "i32"}, {Name: "b", Type: "i32"}, {Name: "c", Type: "i32"}},
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{Value: &ast.BinaryExpr{
+				Op:    ast.OpAdd,
+				Left:  &ast.BinaryExpr{Op: ast.OpAdd, Left: &ast.Ident{Name: "a"}, Right: &ast.Ident{Name: "b"}},
+				Right: &ast.Ident{Name: "c"},
+			}},
+		},
+	}
+
+	g := NewGenerator()
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if !strings.Contains(out, "push rax") {
+		t.Errorf("expected -O0 to keep the literal stack-based codegen, got:\n%s", out)
+	}
+}
+
+func TestBuildVRegChainRejectsCalls(t *testing.T) {
+	_, _, ok := buildVRegChain(&ast.FunctionCallExpr{Callee: "f"})
+	if ok {
+		t.Errorf("expected buildVRegChain to reject call expressions")
+	}
+}
+
+// rightAssocChain builds names[0] + (names[1] + (names[2] + (... +
+// names[len-1]))), the shape that keeps the earliest operands' live
+// intervals open across the whole chain and so is what actually forces
+// linearScanAllocate to spill once there are more live vregs than
+// physRegs.
+func rightAssocChain(names []string) ast.Expr {
+	e := ast.Expr(&ast.Ident{Name: names[len(names)-1]})
+	for i := len(names) - 2; i >= 0; i-- {
+		e = &ast.BinaryExpr{Op: ast.OpAdd, Left: &ast.Ident{Name: names[i]}, Right: e}
+	}
+	return e
+}
+
+func TestLinearScanSpillsWithoutEmittingAMemoryToMemoryInstruction(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	params := make([]ast.Param, len(names))
+	for i, n := range names {
+		params[i] = ast.Param{Name: n, Type: "i32"}
+	}
+	fn := &ast.FunctionDecl{
+		Name:   "sum10",
+		Params: params,
+		Body:   []ast.Stmt{&ast.ReturnStmt{Value: rightAssocChain(names)}},
+	}
+
+	instrs, _, ok := buildVRegChain(rightAssocChain(names))
+	if !ok {
+		t.Fatal("buildVRegChain rejected the chain")
+	}
+	alloc := (&Generator{locals: map[string]int{}}).linearScanAllocate(instrs)
+	spilled := false
+	for _, reg := range alloc {
+		if isMemOperand(reg) {
+			spilled = true
+		}
+	}
+	if !spilled {
+		t.Fatalf("expected a 10-operand chain to outlive the %d physical registers and spill", len(physRegs))
+	}
+
+	g := NewGeneratorWithOptions(GeneratorOptions{OptLevel: 1})
+	out := g.Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Count(line, "[") > 1 {
+			t.Errorf("instruction has two memory operands, which x86-64 can't encode: %q", line)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Package x86 generates x86-64 assembly for a compiled Ferret program.
+//
+// The generator is intentionally staged: function, control-flow and
+// floating-point code generation were added incrementally, and a proper IR
+// and register allocator are expected to replace the current
+// direct-to-assembly walk over the AST.
+package x86
+
+import (
+	"fmt"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+	"github.com/itsfuad/ferret-compiler/compiler/target"
+)
+
+// GeneratorOptions configures how a Generator lowers a program.
+type GeneratorOptions struct {
+	// OptLevel selects which IR optimization passes run before codegen:
+	// 0 disables them and keeps codegen on its most literal, easiest-to-
+	// step-through path (e.g. no register-allocated arithmetic chains);
+	// 1 enables constant folding, dead store elimination and unreachable
+	// block removal (see compiler/ir.Optimize), plus the register-allocated
+	// path for flat +/- chains; 2 additionally runs the peephole pass (see
+	// compiler/ir.Peephole) to drop identity arithmetic like x+0 or x*1
+	// that folding alone can't reach because only one side is a literal.
+	OptLevel int
+
+	// DumpIR, when set, records the IR before and after optimization for
+	// every function so it can be retrieved afterwards via IRDump and
+	// written out by the caller (see `ferret build --dump=ir`), instead of
+	// printing it straight to stdout the way the analyzer's debug trace
+	// still does.
+	DumpIR bool
+
+	// Target selects the output platform's entry symbol and object format.
+	// The zero value is target.Default (Linux).
+	Target target.Target
+
+	// PIC requests position-independent code. The generator already
+	// addresses every global (float constants, runtime scratch space)
+	// RIP-relative via nasm's `default rel`, so this doesn't change what
+	// gets emitted — it's recorded so Generate can say so in the header,
+	// and so callers know to link a PIE/shared object instead of a
+	// fixed-address executable (see driver.LinkOptions.PIC).
+	PIC bool
+
+	// EntryModule marks fns as the program's entry module, i.e. the one
+	// whose `fn main()` the process actually starts at, as opposed to a
+	// module that's only ever called into from elsewhere. It's what tells
+	// Generate to emit a real entry-point trampoline (see entry.go)
+	// instead of treating main as an ordinary function; callers should
+	// have already checked ValidateEntryPoint.
+	EntryModule bool
+}
+
+// Generator accumulates assembly text for a single compilation unit.
+type Generator struct {
+	Options GeneratorOptions
+
+	buf string
+
+	// locals and stackSize track the stack frame of the function currently
+	// being generated; they are reset by generateFunction for each one.
+	locals    map[string]int
+	stackSize int
+
+	labelCount int
+
+	// floatConsts holds the .data entries for floating-point literals,
+	// keyed by their generated label.
+	floatConsts []floatConst
+
+	// stringConsts holds the .data entries for string literals (currently
+	// only the panic messages runtime traps pass to rt_panic), keyed by
+	// their generated label.
+	stringConsts []stringConst
+
+	// runtimeSymbols collects every runtime routine (rt_print_int,
+	// rt_panic, ...) a builtin or trap has called into, so Generate knows
+	// which ones to declare extern.
+	runtimeSymbols map[string]bool
+
+	// moduleName and localFns back symbol mangling (see mangle.go):
+	// moduleName qualifies every function this module defines, and
+	// localFns is the set of names that therefore need mangling at their
+	// call sites too. Extern declarations and the runtime are never in
+	// localFns, since their symbol comes from outside the module.
+	moduleName string
+	localFns   map[string]bool
+
+	// irDump accumulates the text Options.DumpIR requests; see IRDump.
+	irDump string
+
+	// pushDepth counts outstanding pushReg calls not yet matched by popReg,
+	// i.e. bytes temporarily on the stack beyond the aligned frame set up
+	// by generateFunction. callAligned reads it to keep the ABI's 16-byte
+	// alignment-at-call-sites requirement even while a value is parked on
+	// the stack mid-expression (see control_flow.go's push/pop pairs).
+	pushDepth int
+}
+
+// IRDump returns the text recorded for every function while
+// Options.DumpIR was set, in the order they were generated. It's empty if
+// DumpIR was never set.
+func (g *Generator) IRDump() string {
+	return g.irDump
+}
+
+type floatConst struct {
+	label string
+	value float64
+}
+
+type stringConst struct {
+	label string
+	value string
+}
+
+// getNextLabel returns a fresh, function-unique label name for branch
+// targets.
+func (g *Generator) getNextLabel() string {
+	g.labelCount++
+	return fmt.Sprintf(".L%d", g.labelCount)
+}
+
+// NewGenerator creates an empty Generator with optimizations disabled.
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// NewGeneratorWithOptions creates an empty Generator configured by opts.
+func NewGeneratorWithOptions(opts GeneratorOptions) *Generator {
+	return &Generator{Options: opts}
+}
+
+func (g *Generator) emit(format string, args ...interface{}) {
+	g.buf += fmt.Sprintf(format, args...)
+	g.buf += "\n"
+}
+
+// pushReg emits `push reg` and records it in pushDepth so a later call
+// knows the stack has shifted out from under the aligned frame. Every
+// pushReg that isn't immediately followed by its matching popReg on all
+// paths is a potential alignment bug; see callAligned.
+func (g *Generator) pushReg(reg string) {
+	g.emit("    push %s", reg)
+	g.pushDepth++
+}
+
+// popReg emits `pop reg`, undoing the bookkeeping from pushReg.
+func (g *Generator) popReg(reg string) {
+	g.emit("    pop %s", reg)
+	g.pushDepth--
+}
+
+// callAligned emits `call callee`, padding rsp first if an odd number of
+// pushReg calls are currently outstanding (e.g. a binary expression's left
+// operand is parked on the stack while its right operand, itself a call,
+// is evaluated). Without this, such a nested call would run with rsp 8
+// bytes off from the 16-byte alignment the System V ABI requires at the
+// point of `call`.
+func (g *Generator) callAligned(callee string) {
+	misaligned := g.pushDepth%2 != 0
+	if misaligned {
+		g.emit("    sub rsp, 8")
+	}
+	g.emit("    call %s", callee)
+	if misaligned {
+		g.emit("    add rsp, 8")
+	}
+}
+
+// addFloatConstant records value in the .data section and returns the label
+// it was stored under.
+func (g *Generator) addFloatConstant(value float64) string {
+	label := fmt.Sprintf(".LC%d", len(g.floatConsts))
+	g.floatConsts = append(g.floatConsts, floatConst{label: label, value: value})
+	return label
+}
+
+// addStringConstant records value, with a trailing newline appended, in
+// the .data section and returns the label it was stored under along with
+// its total length in bytes — the (msg, len) pair rt_panic expects.
+func (g *Generator) addStringConstant(value string) (label string, length int) {
+	label = fmt.Sprintf(".LS%d", len(g.stringConsts))
+	g.stringConsts = append(g.stringConsts, stringConst{label: label, value: value})
+	return label, len(value) + 1
+}
+
+// callRuntime records that symbol (a routine defined in runtime/rt) is
+// called from generated code, so Generate declares it extern.
+func (g *Generator) callRuntime(symbol string) {
+	if g.runtimeSymbols == nil {
+		g.runtimeSymbols = make(map[string]bool)
+	}
+	g.runtimeSymbols[symbol] = true
+}
+
+// Generate produces the full assembly text for the program. moduleName is
+// used only for the comment header. externs declares the module's `extern
+// fn` bindings, each emitted as a bare extern symbol with no body — the
+// definition comes from whatever object they're linked against.
+func (g *Generator) Generate(moduleName string, fns []*ast.FunctionDecl, externs []*ast.ExternFuncDecl) string {
+	header := fmt.Sprintf("; generated by ferretc — module %s\nbits 64\ndefault rel\n", moduleName)
+	if g.Options.PIC {
+		header += "; position-independent: globals are addressed RIP-relative throughout\n"
+	}
+	header += "\n"
+
+	g.moduleName = moduleName
+	g.localFns = make(map[string]bool, len(fns))
+	for _, fn := range fns {
+		if fn.Name != "main" || g.usesMainTrampoline() {
+			g.localFns[fn.Name] = true
+		}
+	}
+
+	if len(fns) == 0 {
+		entry := g.Options.Target.EntrySymbol()
+		g.emit("section .text")
+		g.emit("global %s", entry)
+		g.emit("%s:", entry)
+		g.emit("    mov eax, 0")
+		g.emit("    ret")
+		return header + g.buf
+	}
+
+	g.emit("section .text")
+	g.generateFunctions(fns)
+
+	out := header
+	for _, e := range externs {
+		out += fmt.Sprintf("extern %s\n", e.Name)
+	}
+	if len(externs) > 0 {
+		out += "\n"
+	}
+	if len(g.runtimeSymbols) > 0 {
+		for _, sym := range sortedKeys(g.runtimeSymbols) {
+			out += fmt.Sprintf("extern %s\n", sym)
+		}
+		out += "\n"
+	}
+	if len(g.floatConsts) > 0 || len(g.stringConsts) > 0 {
+		out += "section .data\n"
+		for _, c := range g.floatConsts {
+			out += fmt.Sprintf("%s: dq %s\n", c.label, formatFloatBits(c.value))
+		}
+		for _, c := range g.stringConsts {
+			out += fmt.Sprintf("%s: db %s, 10\n", c.label, nasmStringLiteral(c.value))
+		}
+		out += "\n"
+	}
+	out += g.buf
+	return out
+}
+
+// sortedKeys returns m's keys in ascending order, so the generated extern
+// declarations (and therefore the assembly output) are deterministic.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	for i := 1; i < len(keys); i++ {
+		for j := i; j > 0 && keys[j-1] > keys[j]; j-- {
+			keys[j-1], keys[j] = keys[j], keys[j-1]
+		}
+	}
+	return keys
+}
+
+// nasmStringLiteral renders s as a nasm `db` operand: a quoted string,
+// since panic messages are fixed ASCII text with no embedded quotes.
+func nasmStringLiteral(s string) string {
+	return fmt.Sprintf("%q", s)
+}
+
+// formatFloatBits renders a float64 the way nasm expects it as a raw
+// double-precision constant.
+func formatFloatBits(v float64) string {
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,25 @@
+package x86
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+func TestGeneratePICAnnotatesHeaderOnly(t *testing.T) {
+	fn := &ast.FunctionDecl{Name: "main", Body: []ast.Stmt{&ast.ReturnStmt{}}}
+
+	plain := NewGenerator().Generate("m", []*ast.FunctionDecl{fn}, nil)
+	pic := NewGeneratorWithOptions(GeneratorOptions{PIC: true}).Generate("m", []*ast.FunctionDecl{fn}, nil)
+
+	if strings.Contains(plain, "position-independent") {
+		t.Errorf("expected no PIC comment without the option, got:\n%s", plain)
+	}
+	if !strings.Contains(pic, "position-independent") {
+		t.Errorf("expected a PIC comment in the header, got:\n%s", pic)
+	}
+	if !strings.Contains(pic, "default rel") || !strings.Contains(plain, "default rel") {
+		t.Error("expected default rel in both cases: addressing doesn't change with PIC")
+	}
+}
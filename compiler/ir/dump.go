@@ -0,0 +1,29 @@
+package ir
+
+import "fmt"
+
+// Dump renders fn as a human-readable textual IR listing, used by
+// -dump=ir and the optimizer's before/after debug traces.
+func Dump(fn *Function) string {
+	out := fmt.Sprintf("func %s:\n", fn.Name)
+	for _, blk := range fn.Blocks {
+		out += fmt.Sprintf("%s:\n", blk.Label)
+		for _, in := range blk.Instrs {
+			switch in.Kind {
+			case InstrAssign:
+				out += fmt.Sprintf("  %s = %v\n", in.Name, in.Value)
+			case InstrEval:
+				out += fmt.Sprintf("  eval %v\n", in.Value)
+			}
+		}
+		switch blk.Term.Kind {
+		case TermReturn:
+			out += fmt.Sprintf("  return %v\n", blk.Term.Value)
+		case TermJump:
+			out += fmt.Sprintf("  jump %s\n", blk.Term.Target)
+		case TermCondJump:
+			out += fmt.Sprintf("  if %v then %s else %s\n", blk.Term.Cond, blk.Term.TrueTarget, blk.Term.FalseTarget)
+		}
+	}
+	return out
+}
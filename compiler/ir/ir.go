@@ -0,0 +1,78 @@
+// Package ir defines a target-independent intermediate representation
+// sitting between the typed AST and a backend's assembly emission.
+//
+// The IR is organized as basic blocks of straight-line instructions ending
+// in a terminator (return/jump/conditional jump), which is enough structure
+// for optimization passes (constant folding, dead code elimination) and
+// multiple backends to share. Instructions still carry their operand
+// expressions as raw *ast.Expr rather than a further-lowered operand form;
+// splitting those into their own instructions is left for a later pass.
+package ir
+
+import "github.com/itsfuad/ferret-compiler/compiler/ast"
+
+// InstrKind identifies the shape of an Instr.
+type InstrKind int
+
+const (
+	// InstrAssign stores the result of evaluating Value into the local
+	// variable Name (corresponds to an ast.VarDecl).
+	InstrAssign InstrKind = iota
+	// InstrEval evaluates Value and discards the result (corresponds to an
+	// ast.ExprStmt), kept for its side effects.
+	InstrEval
+)
+
+// Instr is a single straight-line IR instruction.
+type Instr struct {
+	Kind  InstrKind
+	Name  string // set for InstrAssign
+	Value ast.Expr
+}
+
+// TermKind identifies how a Block transfers control.
+type TermKind int
+
+const (
+	TermReturn TermKind = iota
+	TermJump
+	TermCondJump
+)
+
+// Terminator ends a Block, transferring control elsewhere.
+type Terminator struct {
+	Kind TermKind
+
+	// TermReturn
+	Value ast.Expr // nil for a bare `return`
+
+	// TermJump
+	Target string
+
+	// TermCondJump: branches to FalseTarget when Cond is false, otherwise
+	// falls through to the block immediately following this one in
+	// Function.Blocks (TrueTarget is kept only for readability/dumps).
+	Cond        ast.Expr
+	TrueTarget  string
+	FalseTarget string
+}
+
+// Block is a maximal straight-line sequence of instructions ending in
+// exactly one Terminator.
+type Block struct {
+	Label  string
+	Instrs []Instr
+	Term   Terminator
+
+	// returned tracks, during lowering only, whether Term has already been
+	// set by an explicit return/jump/condjump so later statements in the
+	// same source block are known unreachable.
+	returned bool
+}
+
+// Function is a lowered function ready for backend code generation.
+type Function struct {
+	Name   string
+	Params []ast.Param
+	Blocks []*Block
+}
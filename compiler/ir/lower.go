@@ -0,0 +1,97 @@
+package ir
+
+import (
+	"fmt"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+// builder accumulates the blocks of a single Function being lowered.
+type builder struct {
+	fn         *Function
+	cur        *Block
+	labelCount int
+}
+
+func (b *builder) newLabel(prefix string) string {
+	b.labelCount++
+	return fmt.Sprintf("%s%d", prefix, b.labelCount)
+}
+
+func (b *builder) startBlock(label string) *Block {
+	blk := &Block{Label: label}
+	b.fn.Blocks = append(b.fn.Blocks, blk)
+	b.cur = blk
+	return blk
+}
+
+// Lower translates an AST function into IR basic blocks.
+func Lower(fn *ast.FunctionDecl) *Function {
+	out := &Function{Name: fn.Name, Params: fn.Params}
+	b := &builder{fn: out}
+	b.startBlock("entry")
+	b.lowerStmts(fn.Body)
+	if !b.cur.returned {
+		b.cur.Term = Terminator{Kind: TermReturn}
+		b.cur.returned = true
+	}
+	return out
+}
+
+func (b *builder) lowerStmts(stmts []ast.Stmt) {
+	for _, s := range stmts {
+		if b.cur.returned {
+			// Unreachable code after an explicit return; drop it rather
+			// than attaching it to a block that's already terminated.
+			continue
+		}
+		b.lowerStmt(s)
+	}
+}
+
+func (b *builder) lowerStmt(s ast.Stmt) {
+	switch v := s.(type) {
+	case *ast.VarDecl:
+		b.cur.Instrs = append(b.cur.Instrs, Instr{Kind: InstrAssign, Name: v.Name, Value: v.Init})
+	case *ast.ExprStmt:
+		b.cur.Instrs = append(b.cur.Instrs, Instr{Kind: InstrEval, Value: v.X})
+	case *ast.ReturnStmt:
+		b.cur.Term = Terminator{Kind: TermReturn, Value: v.Value}
+		b.cur.returned = true
+	case *ast.IfStmt:
+		b.lowerIf(v)
+	default:
+		panic(fmt.Sprintf("ir: unhandled statement %T", s))
+	}
+}
+
+func (b *builder) lowerIf(stmt *ast.IfStmt) {
+	mergeLabel := b.newLabel("merge")
+	falseLabel := mergeLabel
+	if len(stmt.Else) > 0 {
+		falseLabel = b.newLabel("else")
+	}
+	trueLabel := b.newLabel("then")
+
+	entry := b.cur
+	entry.Term = Terminator{Kind: TermCondJump, Cond: stmt.Cond, TrueTarget: trueLabel, FalseTarget: falseLabel}
+	entry.returned = true
+
+	b.startBlock(trueLabel)
+	b.lowerStmts(stmt.Then)
+	if !b.cur.returned {
+		b.cur.Term = Terminator{Kind: TermJump, Target: mergeLabel}
+		b.cur.returned = true
+	}
+
+	if len(stmt.Else) > 0 {
+		b.startBlock(falseLabel)
+		b.lowerStmts(stmt.Else)
+		if !b.cur.returned {
+			b.cur.Term = Terminator{Kind: TermJump, Target: mergeLabel}
+			b.cur.returned = true
+		}
+	}
+
+	b.startBlock(mergeLabel)
+}
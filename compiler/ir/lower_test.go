@@ -0,0 +1,42 @@
+package ir
+
+import (
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+func TestLowerIfElseProducesThreeBlocksPlusMerge(t *testing.T) {
+	fn := &ast.FunctionDecl{
+		Name: "f",
+		Body: []ast.Stmt{
+			&ast.IfStmt{
+				Cond: &ast.IntLiteral{Value: 1},
+				Then: []ast.Stmt{&ast.ReturnStmt{Value: &ast.IntLiteral{Value: 1}}},
+				Else: []ast.Stmt{&ast.ReturnStmt{Value: &ast.IntLiteral{Value: 0}}},
+			},
+		},
+	}
+
+	out := Lower(fn)
+
+	if len(out.Blocks) != 4 {
+		t.Fatalf("expected entry/then/else/merge blocks, got %d", len(out.Blocks))
+	}
+	entry := out.Blocks[0]
+	if entry.Term.Kind != TermCondJump {
+		t.Errorf("expected entry block to end in a conditional jump, got %v", entry.Term.Kind)
+	}
+}
+
+func TestLowerImplicitVoidReturn(t *testing.T) {
+	fn := &ast.FunctionDecl{Name: "f", Body: nil}
+	out := Lower(fn)
+
+	if len(out.Blocks) != 1 {
+		t.Fatalf("expected a single entry block, got %d", len(out.Blocks))
+	}
+	if out.Blocks[0].Term.Kind != TermReturn {
+		t.Errorf("expected an implicit return at the end of an empty body")
+	}
+}
@@ -0,0 +1,278 @@
+package ir
+
+import "github.com/itsfuad/ferret-compiler/compiler/ast"
+
+// Optimize runs the IR-level optimization passes in a fixed pipeline:
+// constant folding, then dead store elimination, then unreachable block
+// removal (folding can turn a CondJump into an always-taken branch, which
+// is what usually makes a block unreachable in the first place).
+func Optimize(fn *Function) {
+	constantFold(fn)
+	eliminateDeadStores(fn)
+	removeUnreachableBlocks(fn)
+}
+
+// Peephole runs the peephole pass over every instruction and terminator,
+// simplifying identity arithmetic (x+0, x*1, x-0, x*0, ...) that
+// constantFold can't touch because only one side is a literal. Callers run
+// this after Optimize, at -O2, on the theory that folding and dead store
+// elimination can expose new identities (e.g. a folded 0 feeding a
+// multiply) for it to clean up.
+func Peephole(fn *Function) {
+	for _, blk := range fn.Blocks {
+		for i := range blk.Instrs {
+			blk.Instrs[i].Value = simplifyIdentities(blk.Instrs[i].Value)
+		}
+		blk.Term.Value = simplifyIdentities(blk.Term.Value)
+		blk.Term.Cond = simplifyIdentities(blk.Term.Cond)
+	}
+}
+
+// simplifyIdentities rewrites a binary expression with a literal identity
+// operand down to its non-literal side (or to a literal zero for x*0),
+// recursing into both operands first so a nested identity collapses before
+// its parent is examined.
+func simplifyIdentities(e ast.Expr) ast.Expr {
+	bin, ok := e.(*ast.BinaryExpr)
+	if !ok {
+		return e
+	}
+	left := simplifyIdentities(bin.Left)
+	right := simplifyIdentities(bin.Right)
+
+	switch bin.Op {
+	case ast.OpAdd:
+		if isIntLiteral(left, 0) {
+			return right
+		}
+		if isIntLiteral(right, 0) {
+			return left
+		}
+	case ast.OpSub:
+		if isIntLiteral(right, 0) {
+			return left
+		}
+	case ast.OpMul:
+		if isIntLiteral(left, 0) && sideEffectFree(right) {
+			return &ast.IntLiteral{Value: 0}
+		}
+		if isIntLiteral(right, 0) && sideEffectFree(left) {
+			return &ast.IntLiteral{Value: 0}
+		}
+		if isIntLiteral(left, 1) {
+			return right
+		}
+		if isIntLiteral(right, 1) {
+			return left
+		}
+	}
+	return &ast.BinaryExpr{Op: bin.Op, Left: left, Right: right}
+}
+
+func isIntLiteral(e ast.Expr, want int64) bool {
+	lit, ok := e.(*ast.IntLiteral)
+	return ok && lit.Value == want
+}
+
+// sideEffectFree reports whether evaluating e can only ever read values,
+// never run code with an observable effect. The x*0 identity is only safe
+// to replace with a bare zero literal when the discarded operand is one of
+// these — a *ast.FunctionCallExpr anywhere inside it (even nested in a
+// sub-expression we'd otherwise consider droppable) means the call still
+// has to run for its side effects, so the multiply can't just vanish.
+func sideEffectFree(e ast.Expr) bool {
+	switch v := e.(type) {
+	case *ast.Ident, *ast.IntLiteral:
+		return true
+	case *ast.BinaryExpr:
+		return sideEffectFree(v.Left) && sideEffectFree(v.Right)
+	case *ast.FloatBinaryExpr:
+		return sideEffectFree(v.Left) && sideEffectFree(v.Right)
+	case *ast.CastExpr:
+		return sideEffectFree(v.Value)
+	default:
+		// *ast.FunctionCallExpr and anything else this pass doesn't know
+		// to be safe are assumed to have a side effect.
+		return false
+	}
+}
+
+// constantFold replaces binary expressions over two integer literals with
+// the single literal they compute to, both in instruction values and in
+// terminator conditions/return values.
+func constantFold(fn *Function) {
+	for _, blk := range fn.Blocks {
+		for i := range blk.Instrs {
+			blk.Instrs[i].Value = foldExpr(blk.Instrs[i].Value)
+		}
+		blk.Term.Value = foldExpr(blk.Term.Value)
+		blk.Term.Cond = foldExpr(blk.Term.Cond)
+	}
+}
+
+func foldExpr(e ast.Expr) ast.Expr {
+	if e == nil {
+		return nil
+	}
+	bin, ok := e.(*ast.BinaryExpr)
+	if !ok {
+		return e
+	}
+	left := foldExpr(bin.Left)
+	right := foldExpr(bin.Right)
+
+	l, lok := left.(*ast.IntLiteral)
+	r, rok := right.(*ast.IntLiteral)
+	if !lok || !rok {
+		return &ast.BinaryExpr{Op: bin.Op, Left: left, Right: right}
+	}
+
+	switch bin.Op {
+	case ast.OpAdd:
+		return &ast.IntLiteral{Value: l.Value + r.Value}
+	case ast.OpSub:
+		return &ast.IntLiteral{Value: l.Value - r.Value}
+	case ast.OpMul:
+		return &ast.IntLiteral{Value: l.Value * r.Value}
+	// OpDiv is deliberately not folded: a zero divisor must still reach
+	// the codegen-emitted runtime trap instead of panicking the compiler.
+	case ast.OpEq:
+		return boolLiteral(l.Value == r.Value)
+	case ast.OpLt:
+		return boolLiteral(l.Value < r.Value)
+	default:
+		return &ast.BinaryExpr{Op: bin.Op, Left: left, Right: right}
+	}
+}
+
+func boolLiteral(b bool) *ast.IntLiteral {
+	if b {
+		return &ast.IntLiteral{Value: 1}
+	}
+	return &ast.IntLiteral{Value: 0}
+}
+
+// eliminateDeadStores drops Assign instructions whose local is never read
+// anywhere else in the function. This is a whole-function, not per-path,
+// liveness check, which is conservative enough to always be safe: a local
+// that's read on no path is read on no path, period — but that only
+// justifies dropping the binding, not skipping its initializer. A dead
+// store whose Value contains a call is demoted to a bare InstrEval instead
+// of being deleted outright, so the call still runs for its side effects.
+func eliminateDeadStores(fn *Function) {
+	used := map[string]bool{}
+	for _, blk := range fn.Blocks {
+		for _, in := range blk.Instrs {
+			markUsedIdents(in.Value, used)
+		}
+		markUsedIdents(blk.Term.Value, used)
+		markUsedIdents(blk.Term.Cond, used)
+	}
+
+	for _, blk := range fn.Blocks {
+		kept := blk.Instrs[:0]
+		for _, in := range blk.Instrs {
+			if in.Kind == InstrAssign && !used[in.Name] {
+				if containsFunctionCall(in.Value) {
+					kept = append(kept, Instr{Kind: InstrEval, Value: in.Value})
+				}
+				continue
+			}
+			kept = append(kept, in)
+		}
+		blk.Instrs = kept
+	}
+}
+
+// containsFunctionCall reports whether e is, or contains anywhere within
+// it, a *ast.FunctionCallExpr — the condition under which a dead store
+// can't just be deleted, since the call inside it still has to run.
+func containsFunctionCall(e ast.Expr) bool {
+	switch v := e.(type) {
+	case *ast.FunctionCallExpr:
+		return true
+	case *ast.BinaryExpr:
+		return containsFunctionCall(v.Left) || containsFunctionCall(v.Right)
+	case *ast.FloatBinaryExpr:
+		return containsFunctionCall(v.Left) || containsFunctionCall(v.Right)
+	case *ast.CastExpr:
+		return containsFunctionCall(v.Value)
+	default:
+		return false
+	}
+}
+
+func markUsedIdents(e ast.Expr, used map[string]bool) {
+	switch v := e.(type) {
+	case nil:
+	case *ast.Ident:
+		used[v.Name] = true
+	case *ast.BinaryExpr:
+		markUsedIdents(v.Left, used)
+		markUsedIdents(v.Right, used)
+	case *ast.FloatBinaryExpr:
+		markUsedIdents(v.Left, used)
+		markUsedIdents(v.Right, used)
+	case *ast.CastExpr:
+		markUsedIdents(v.Value, used)
+	case *ast.FunctionCallExpr:
+		for _, a := range v.Args {
+			markUsedIdents(a, used)
+		}
+	}
+}
+
+// removeUnreachableBlocks drops blocks that no path from the entry block
+// can reach, e.g. a merge block left behind when both sides of an if/else
+// return directly.
+func removeUnreachableBlocks(fn *Function) {
+	reachable := ReachableLabels(fn)
+	kept := fn.Blocks[:0]
+	for _, blk := range fn.Blocks {
+		if reachable[blk.Label] {
+			kept = append(kept, blk)
+		}
+	}
+	fn.Blocks = kept
+}
+
+// ReachableLabels returns the set of block labels reachable from fn's entry
+// block by following Jump/CondJump targets. It's exported so callers that
+// only want to report unreachable code (e.g. a `ferret vet` lint rule)
+// rather than silently dropping it, the way removeUnreachableBlocks does,
+// can reuse the same traversal.
+func ReachableLabels(fn *Function) map[string]bool {
+	if len(fn.Blocks) == 0 {
+		return map[string]bool{}
+	}
+
+	byLabel := map[string]*Block{}
+	for _, blk := range fn.Blocks {
+		byLabel[blk.Label] = blk
+	}
+
+	reachable := map[string]bool{fn.Blocks[0].Label: true}
+	worklist := []*Block{fn.Blocks[0]}
+	for len(worklist) > 0 {
+		blk := worklist[len(worklist)-1]
+		worklist = worklist[:len(worklist)-1]
+
+		var targets []string
+		switch blk.Term.Kind {
+		case TermJump:
+			targets = []string{blk.Term.Target}
+		case TermCondJump:
+			targets = []string{blk.Term.TrueTarget, blk.Term.FalseTarget}
+		}
+		for _, t := range targets {
+			if reachable[t] {
+				continue
+			}
+			reachable[t] = true
+			if next, ok := byLabel[t]; ok {
+				worklist = append(worklist, next)
+			}
+		}
+	}
+	return reachable
+}
@@ -0,0 +1,157 @@
+package ir
+
+import (
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+)
+
+func TestConstantFoldsAddition(t *testing.T) {
+	fn := &Function{Blocks: []*Block{{
+		Label: "entry",
+		Term: Terminator{Kind: TermReturn, Value: &ast.BinaryExpr{
+			Op: ast.OpAdd, Left: &ast.IntLiteral{Value: 2}, Right: &ast.IntLiteral{Value: 3},
+		}},
+	}}}
+
+	Optimize(fn)
+
+	lit, ok := fn.Blocks[0].Term.Value.(*ast.IntLiteral)
+	if !ok || lit.Value != 5 {
+		t.Errorf("expected 2+3 to fold to IntLiteral{5}, got %#v", fn.Blocks[0].Term.Value)
+	}
+}
+
+func TestConstantFoldsMultiplicationButNotDivision(t *testing.T) {
+	fn := &Function{Blocks: []*Block{{
+		Label: "entry",
+		Instrs: []Instr{
+			{Kind: InstrAssign, Name: "mul", Value: &ast.BinaryExpr{
+				Op: ast.OpMul, Left: &ast.IntLiteral{Value: 4}, Right: &ast.IntLiteral{Value: 5},
+			}},
+			{Kind: InstrAssign, Name: "div", Value: &ast.BinaryExpr{
+				Op: ast.OpDiv, Left: &ast.IntLiteral{Value: 10}, Right: &ast.IntLiteral{Value: 0},
+			}},
+		},
+		Term: Terminator{Kind: TermReturn, Value: &ast.Ident{Name: "mul"}},
+	}}}
+
+	constantFold(fn)
+
+	mul, ok := fn.Blocks[0].Instrs[0].Value.(*ast.IntLiteral)
+	if !ok || mul.Value != 20 {
+		t.Errorf("expected 4*5 to fold to IntLiteral{20}, got %#v", fn.Blocks[0].Instrs[0].Value)
+	}
+	if _, ok := fn.Blocks[0].Instrs[1].Value.(*ast.IntLiteral); ok {
+		t.Errorf("expected division to be left unfolded so a zero divisor still traps at run time, got %#v", fn.Blocks[0].Instrs[1].Value)
+	}
+}
+
+func TestDeadStoreElimination(t *testing.T) {
+	fn := &Function{Blocks: []*Block{{
+		Label: "entry",
+		Instrs: []Instr{
+			{Kind: InstrAssign, Name: "unused", Value: &ast.IntLiteral{Value: 1}},
+			{Kind: InstrAssign, Name: "used", Value: &ast.IntLiteral{Value: 2}},
+		},
+		Term: Terminator{Kind: TermReturn, Value: &ast.Ident{Name: "used"}},
+	}}}
+
+	Optimize(fn)
+
+	for _, in := range fn.Blocks[0].Instrs {
+		if in.Name == "unused" {
+			t.Errorf("expected the dead store to %q to be removed", in.Name)
+		}
+	}
+}
+
+func TestDeadStoreEliminationKeepsACallAsAnEval(t *testing.T) {
+	fn := &Function{Blocks: []*Block{{
+		Label: "entry",
+		Instrs: []Instr{
+			{Kind: InstrAssign, Name: "unused", Value: &ast.FunctionCallExpr{Callee: "sideEffect"}},
+		},
+		Term: Terminator{Kind: TermReturn},
+	}}}
+
+	Optimize(fn)
+
+	if len(fn.Blocks[0].Instrs) != 1 {
+		t.Fatalf("expected the call to survive as one instruction, got %#v", fn.Blocks[0].Instrs)
+	}
+	in := fn.Blocks[0].Instrs[0]
+	if in.Kind != InstrEval {
+		t.Errorf("expected the dead store to be demoted to InstrEval, got %#v", in)
+	}
+	if _, ok := in.Value.(*ast.FunctionCallExpr); !ok {
+		t.Errorf("expected the call to survive as the instruction's value, got %#v", in.Value)
+	}
+}
+
+func TestPeepholeDropsIdentityArithmetic(t *testing.T) {
+	fn := &Function{Blocks: []*Block{{
+		Label: "entry",
+		Instrs: []Instr{
+			{Kind: InstrAssign, Name: "a", Value: &ast.BinaryExpr{
+				Op: ast.OpAdd, Left: &ast.Ident{Name: "x"}, Right: &ast.IntLiteral{Value: 0},
+			}},
+			{Kind: InstrAssign, Name: "b", Value: &ast.BinaryExpr{
+				Op: ast.OpMul, Left: &ast.IntLiteral{Value: 1}, Right: &ast.Ident{Name: "y"},
+			}},
+			{Kind: InstrAssign, Name: "c", Value: &ast.BinaryExpr{
+				Op: ast.OpMul, Left: &ast.Ident{Name: "z"}, Right: &ast.IntLiteral{Value: 0},
+			}},
+		},
+		Term: Terminator{Kind: TermReturn, Value: &ast.Ident{Name: "a"}},
+	}}}
+
+	Peephole(fn)
+
+	if ident, ok := fn.Blocks[0].Instrs[0].Value.(*ast.Ident); !ok || ident.Name != "x" {
+		t.Errorf("expected x+0 to simplify to x, got %#v", fn.Blocks[0].Instrs[0].Value)
+	}
+	if ident, ok := fn.Blocks[0].Instrs[1].Value.(*ast.Ident); !ok || ident.Name != "y" {
+		t.Errorf("expected 1*y to simplify to y, got %#v", fn.Blocks[0].Instrs[1].Value)
+	}
+	if lit, ok := fn.Blocks[0].Instrs[2].Value.(*ast.IntLiteral); !ok || lit.Value != 0 {
+		t.Errorf("expected z*0 to simplify to 0, got %#v", fn.Blocks[0].Instrs[2].Value)
+	}
+}
+
+func TestPeepholeKeepsASideEffectingOperandOfMulByZero(t *testing.T) {
+	fn := &Function{Blocks: []*Block{{
+		Label: "entry",
+		Instrs: []Instr{
+			{Kind: InstrAssign, Name: "a", Value: &ast.BinaryExpr{
+				Op:    ast.OpMul,
+				Left:  &ast.FunctionCallExpr{Callee: "sideEffect"},
+				Right: &ast.IntLiteral{Value: 0},
+			}},
+		},
+		Term: Terminator{Kind: TermReturn, Value: &ast.Ident{Name: "a"}},
+	}}}
+
+	Peephole(fn)
+
+	bin, ok := fn.Blocks[0].Instrs[0].Value.(*ast.BinaryExpr)
+	if !ok {
+		t.Fatalf("expected sideEffect()*0 to stay a BinaryExpr so the call still runs, got %#v", fn.Blocks[0].Instrs[0].Value)
+	}
+	if _, ok := bin.Left.(*ast.FunctionCallExpr); !ok {
+		t.Errorf("expected the call to survive as the left operand, got %#v", bin.Left)
+	}
+}
+
+func TestRemoveUnreachableBlocks(t *testing.T) {
+	fn := &Function{Blocks: []*Block{
+		{Label: "entry", Term: Terminator{Kind: TermReturn}},
+		{Label: "dead", Term: Terminator{Kind: TermReturn}},
+	}}
+
+	Optimize(fn)
+
+	if len(fn.Blocks) != 1 {
+		t.Fatalf("expected the unreferenced block to be removed, got %d blocks", len(fn.Blocks))
+	}
+}
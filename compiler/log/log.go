@@ -0,0 +1,46 @@
+// Package log is a minimal leveled logger for the compiler's own internal
+// tracing — decisions library code wants to explain while debugging, not
+// diagnostics a user of the compiler should see (see compiler/report for
+// those). It exists so library code stops calling fmt.Printf directly: a
+// caller that never attaches a Logger gets silence for free, instead of
+// the library deciding for itself when it's safe to print.
+package log
+
+import (
+	"fmt"
+	"io"
+)
+
+// Level orders how much a Logger prints; a message is written only if its
+// own level is at or below the Logger's configured Level.
+type Level int
+
+const (
+	// LevelSilent discards every message. It's the zero Level, so a zero
+	// Logger is silent, same as a nil *Logger (see Debugf).
+	LevelSilent Level = iota
+	LevelDebug
+)
+
+// Logger writes leveled trace messages to Out. A nil *Logger is silent, so
+// library code can hold one unconditionally instead of also tracking
+// whether logging is enabled.
+type Logger struct {
+	Level Level
+	Out   io.Writer
+}
+
+// New creates a Logger at level, writing to out.
+func New(level Level, out io.Writer) *Logger {
+	return &Logger{Level: level, Out: out}
+}
+
+// Debugf writes a LevelDebug message, formatted like fmt.Printf with a
+// trailing newline. It does nothing if l is nil or configured below
+// LevelDebug.
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	if l == nil || l.Level < LevelDebug {
+		return
+	}
+	fmt.Fprintf(l.Out, format+"\n", args...)
+}
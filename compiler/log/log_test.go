@@ -0,0 +1,35 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDebugfWritesAtOrAboveItsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LevelDebug, &buf)
+
+	l.Debugf("x = %d", 1)
+
+	if !strings.Contains(buf.String(), "x = 1") {
+		t.Errorf("expected the message to be written, got %q", buf.String())
+	}
+}
+
+func TestDebugfIsSilentBelowItsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LevelSilent, &buf)
+
+	l.Debugf("x = %d", 1)
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no output at LevelSilent, got %q", buf.String())
+	}
+}
+
+func TestDebugfOnANilLoggerIsSilent(t *testing.T) {
+	var l *Logger
+
+	l.Debugf("x = %d", 1)
+}
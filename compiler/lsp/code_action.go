@@ -0,0 +1,55 @@
+package lsp
+
+import (
+	"encoding/json"
+
+	"github.com/itsfuad/ferret-compiler/compiler/report"
+)
+
+// CodeAction is one quick fix returned by textDocument/codeAction, per the
+// spec.
+type CodeAction struct {
+	Title string        `json:"title"`
+	Edit  WorkspaceEdit `json:"edit"`
+}
+
+// FixToCodeAction converts a report.Fix attached to a diagnostic in uri
+// into the WorkspaceEdit-carrying CodeAction an editor applies with one
+// click. report.Position is 1-based (line, column); LSP Position is
+// 0-based (line, character), so every coordinate is shifted by one.
+func FixToCodeAction(uri string, fix report.Fix) CodeAction {
+	edits := make([]TextEdit, len(fix.Edits))
+	for i, e := range fix.Edits {
+		edits[i] = TextEdit{Range: toLSPRange(e.Range), NewText: e.NewText}
+	}
+	return CodeAction{
+		Title: fix.Description,
+		Edit:  WorkspaceEdit{Changes: map[string][]TextEdit{uri: edits}},
+	}
+}
+
+func toLSPRange(r report.Range) Range {
+	return Range{
+		Start: Position{Line: r.Start.Line - 1, Character: r.Start.Column - 1},
+		End:   Position{Line: r.End.Line - 1, Character: r.End.Column - 1},
+	}
+}
+
+// RegisterCodeAction wires textDocument/codeAction. Converting a Report's
+// Fixes into CodeActions is handled by FixToCodeAction above and ready to
+// use, but this server doesn't publish diagnostics yet (see
+// RegisterDidClose), so it has no Report to look one up from the
+// requested range — it always answers with no actions until that wiring
+// exists.
+func RegisterCodeAction(s *Server) {
+	s.Handle("textDocument/codeAction", func(params json.RawMessage) (interface{}, error) {
+		var p struct {
+			TextDocument TextDocumentIdentifier `json:"textDocument"`
+			Range        Range                  `json:"range"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return []CodeAction{}, nil
+	})
+}
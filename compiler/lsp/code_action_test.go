@@ -0,0 +1,51 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/report"
+)
+
+func TestFixToCodeActionConvertsEditsAndShiftsToZeroBasedPositions(t *testing.T) {
+	fix := report.Fix{
+		Description: "Write `as i32` after the expression",
+		Edits: []report.Edit{
+			{Range: report.Range{Start: report.Position{Line: 3, Column: 5}, End: report.Position{Line: 3, Column: 8}}, NewText: "x as i32"},
+		},
+	}
+
+	action := FixToCodeAction("file:///a.fer", fix)
+
+	if action.Title != fix.Description {
+		t.Errorf("expected the fix description as the action title, got %q", action.Title)
+	}
+	edits := action.Edit.Changes["file:///a.fer"]
+	if len(edits) != 1 {
+		t.Fatalf("expected one edit, got %d", len(edits))
+	}
+	if edits[0].Range.Start != (Position{Line: 2, Character: 4}) {
+		t.Errorf("expected the 1-based report position shifted to 0-based, got %+v", edits[0].Range.Start)
+	}
+	if edits[0].NewText != "x as i32" {
+		t.Errorf("expected the new text carried over, got %q", edits[0].NewText)
+	}
+}
+
+func TestCodeActionReturnsNoActionsWithNoPublishedDiagnostics(t *testing.T) {
+	s := New()
+	RegisterCodeAction(s)
+
+	params, _ := json.Marshal(map[string]interface{}{"textDocument": TextDocumentIdentifier{URI: "file:///a.fer"}, "range": Range{}})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/codeAction", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"result":[]`) {
+		t.Errorf("expected an empty action list, got %q", out.String())
+	}
+}
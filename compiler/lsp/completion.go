@@ -0,0 +1,59 @@
+package lsp
+
+import (
+	"encoding/json"
+
+	"github.com/itsfuad/ferret-compiler/compiler/types"
+)
+
+// CompletionItemKind mirrors the subset of the LSP's CompletionItemKind
+// enum this server emits.
+const (
+	CompletionItemKindKeyword = 14
+)
+
+// CompletionItem is one entry in a textDocument/completion response, per
+// the spec.
+type CompletionItem struct {
+	Label string `json:"label"`
+	Kind  int    `json:"kind"`
+}
+
+// builtinTypeNames are the primitive type names types.IsNumeric and its
+// neighbors know about (see compiler/types), the one set of identifiers
+// this compiler can name with certainty regardless of what's in the
+// current file — there's no scope, module or builtin-function table to
+// complete against yet.
+var builtinTypeNames = []string{
+	types.I8.Name, types.I16.Name, types.I32.Name, types.I64.Name,
+	types.F32.Name, types.F64.Name, types.Bool.Name, types.Str.Name,
+}
+
+// RegisterCompletion wires textDocument/completion. Until there's a scope
+// to resolve the current module's own symbols against, it completes only
+// the builtin primitive type names — real, always-valid completions,
+// rather than the empty list RegisterDefinition and RegisterHover fall
+// back to, since at least those names are knowable today.
+//
+// It deliberately doesn't offer snippet completions for constructs like a
+// function, an if/else, a struct literal, or an import: compiler/ast has
+// FunctionDecl, IfStmt and VarDecl nodes, which is suggestive of `fn` and
+// `if`/`else` as keywords, but there's no struct-literal or import node at
+// all, and — more fundamentally — no lexer anywhere in this repo to say
+// what token actually spells any of these in source. Shipping a snippet
+// for "fn" would be guessing at concrete surface syntax this compiler
+// hasn't defined yet; that has to wait for a lexer and grammar to exist.
+func RegisterCompletion(s *Server) {
+	s.Handle("textDocument/completion", func(params json.RawMessage) (interface{}, error) {
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		items := make([]CompletionItem, 0, len(builtinTypeNames))
+		for _, name := range builtinTypeNames {
+			items = append(items, CompletionItem{Label: name, Kind: CompletionItemKindKeyword})
+		}
+		return items, nil
+	})
+}
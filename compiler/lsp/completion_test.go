@@ -0,0 +1,46 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCompletionListsBuiltinTypeNames(t *testing.T) {
+	s := New()
+	RegisterCompletion(s)
+
+	params, _ := json.Marshal(TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"},
+		Position:     Position{Line: 0, Character: 0},
+	})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/completion", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	for _, name := range []string{"i32", "bool", "str"} {
+		if !strings.Contains(out.String(), `"label":"`+name+`"`) {
+			t.Errorf("expected completion to include builtin type %q, got %q", name, out.String())
+		}
+	}
+}
+
+func TestInitializeAdvertisesCompletionProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterCompletion(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"completionProvider"`) {
+		t.Errorf("expected completionProvider once RegisterCompletion ran, got %q", out.String())
+	}
+}
@@ -0,0 +1,48 @@
+package lsp
+
+import "encoding/json"
+
+// setSettings records the client's current configuration, sent once via
+// initialize's initializationOptions and kept current by
+// workspace/didChangeConfiguration. It's stored as raw JSON rather than a
+// fixed struct because this server advertises no configuration schema of
+// its own yet: debug logging, an analysis mode switch, a max-diagnostics
+// cap and a builtin-modules path are all plausible settings a client might
+// send, but nothing downstream reads any of them today (there's no
+// structured logging hook, no published diagnostics to cap, and the
+// completions this package offers come from compiler/types, not a
+// configurable path). Keeping the raw value means a feature that wants one
+// of these settings later just unmarshals Settings() into its own struct,
+// instead of this plumbing having to be added alongside it.
+func (s *Server) setSettings(raw json.RawMessage) {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	s.settings = append(json.RawMessage(nil), raw...)
+}
+
+// Settings returns the most recently received configuration, or nil if the
+// client has never sent any (no initializationOptions and no
+// workspace/didChangeConfiguration).
+func (s *Server) Settings() json.RawMessage {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	return append(json.RawMessage(nil), s.settings...)
+}
+
+type didChangeConfigurationParams struct {
+	Settings json.RawMessage `json:"settings"`
+}
+
+// RegisterConfiguration wires workspace/didChangeConfiguration, so settings
+// sent once via initializationOptions can be updated later without a
+// restart.
+func RegisterConfiguration(s *Server) {
+	s.Handle("workspace/didChangeConfiguration", func(params json.RawMessage) (interface{}, error) {
+		var p didChangeConfigurationParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		s.setSettings(p.Settings)
+		return nil, nil
+	})
+}
@@ -0,0 +1,49 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInitializeRecordsInitializationOptionsAsSettings(t *testing.T) {
+	s := New()
+	RegisterLifecycle(s)
+
+	params, _ := json.Marshal(map[string]interface{}{
+		"initializationOptions": map[string]interface{}{"maxDiagnostics": 100},
+	})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize", Params: params})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(string(s.Settings()), `"maxDiagnostics":100`) {
+		t.Errorf("expected initializationOptions recorded as settings, got %q", s.Settings())
+	}
+}
+
+func TestDidChangeConfigurationUpdatesSettings(t *testing.T) {
+	s := New()
+	RegisterConfiguration(s)
+
+	params, _ := json.Marshal(didChangeConfigurationParams{Settings: json.RawMessage(`{"debug":true}`)})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", Method: "workspace/didChangeConfiguration", Params: params})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(string(s.Settings()), `"debug":true`) {
+		t.Errorf("expected didChangeConfiguration to update settings, got %q", s.Settings())
+	}
+}
+
+func TestSettingsStartsNil(t *testing.T) {
+	s := New()
+	if s.Settings() != nil {
+		t.Errorf("expected no settings before any are sent, got %q", s.Settings())
+	}
+}
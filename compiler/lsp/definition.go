@@ -0,0 +1,32 @@
+package lsp
+
+import "encoding/json"
+
+// Location is a span in a document, the shape textDocument/definition and
+// textDocument/references both answer with.
+type Location struct {
+	URI   string `json:"uri"`
+	Range Range  `json:"range"`
+}
+
+// Range is a span between two Positions, per the LSP spec.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// RegisterDefinition wires textDocument/definition. There is no symbol
+// table to resolve a cursor position against yet — no frontend populates
+// one — so it always answers "no definition found" (a null result, which
+// the spec allows), the same honest way build's --dump tokens/ast stages
+// report having nothing to show yet. Replace the handler body once a
+// position -> declaration lookup exists.
+func RegisterDefinition(s *Server) {
+	s.Handle("textDocument/definition", func(params json.RawMessage) (interface{}, error) {
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
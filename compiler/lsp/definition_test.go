@@ -0,0 +1,47 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDefinitionReturnsNullWithNoSymbolTable(t *testing.T) {
+	s := New()
+	RegisterDefinition(s)
+
+	params, _ := json.Marshal(TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"},
+		Position:     Position{Line: 0, Character: 0},
+	})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/definition", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if strings.Contains(out.String(), `"error"`) {
+		t.Errorf("expected no error for definition with no symbol table, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), `"id":1`) {
+		t.Errorf("expected a successful response for the request id, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesDefinitionProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterDefinition(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"definitionProvider":true`) {
+		t.Errorf("expected definitionProvider:true once RegisterDefinition ran, got %q", out.String())
+	}
+}
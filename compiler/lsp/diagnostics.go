@@ -0,0 +1,106 @@
+package lsp
+
+import "github.com/itsfuad/ferret-compiler/compiler/report"
+
+// DiagnosticSeverityError, ...Warning, ...Information and ...Hint are the
+// four DiagnosticSeverity values LSP defines; this server only ever
+// produces the first two, since report.Severity has no Information or Hint
+// level.
+const (
+	DiagnosticSeverityError       = 1
+	DiagnosticSeverityWarning     = 2
+	DiagnosticSeverityInformation = 3
+	DiagnosticSeverityHint        = 4
+)
+
+// DiagnosticTagUnnecessary and DiagnosticTagDeprecated are the two
+// DiagnosticTag values LSP defines, letting an editor render a diagnostic
+// as faded-out or strikethrough instead of just underlined.
+const (
+	DiagnosticTagUnnecessary = 1
+	DiagnosticTagDeprecated  = 2
+)
+
+// Diagnostic is one entry of textDocument/publishDiagnostics's
+// diagnostics list, per the spec.
+//
+// Tags is always empty: setting DiagnosticTagUnnecessary needs an
+// unused-symbol analysis and DiagnosticTagDeprecated needs a notion of a
+// deprecated declaration to check a reference against, and this compiler
+// has neither — there's no scope or symbol table at all (see
+// RegisterCompletion), so nothing can tell a used identifier from an
+// unused one, let alone a deprecated one from a current one.
+type Diagnostic struct {
+	Range              Range                          `json:"range"`
+	Severity           int                            `json:"severity,omitempty"`
+	Code               string                         `json:"code,omitempty"`
+	Source             string                         `json:"source,omitempty"`
+	Message            string                         `json:"message"`
+	Tags               []int                          `json:"tags,omitempty"`
+	RelatedInformation []DiagnosticRelatedInformation `json:"relatedInformation,omitempty"`
+}
+
+// DiagnosticRelatedInformation points a Diagnostic at another location
+// relevant to it, the LSP analogue of report.RelatedSpan.
+type DiagnosticRelatedInformation struct {
+	Location Location `json:"location"`
+	Message  string   `json:"message"`
+}
+
+// diagnosticSource is the Diagnostic.Source every conversion below sets,
+// identifying this compiler to an editor showing diagnostics from several
+// sources in one list.
+const diagnosticSource = "ferret"
+
+// ReportToDiagnostic converts a report.Report into the Diagnostic an LSP
+// client expects, carrying over its RelatedSpans as RelatedInformation.
+// report.Position is 1-based; LSP Position is 0-based, so every coordinate
+// is shifted the same way FixToCodeAction shifts a Fix's.
+func ReportToDiagnostic(r report.Report) Diagnostic {
+	related := make([]DiagnosticRelatedInformation, len(r.Related))
+	for i, rel := range r.Related {
+		related[i] = DiagnosticRelatedInformation{
+			Location: Location{URI: fileURI(rel.File), Range: toLSPRange(rel.Range)},
+			Message:  rel.Message,
+		}
+	}
+	return Diagnostic{
+		Range:              toLSPRange(r.Range),
+		Severity:           diagnosticSeverity(r.Severity),
+		Code:               string(r.Code),
+		Source:             diagnosticSource,
+		Message:            r.Message,
+		RelatedInformation: related,
+	}
+}
+
+func diagnosticSeverity(s report.Severity) int {
+	if s == report.SeverityWarning {
+		return DiagnosticSeverityWarning
+	}
+	return DiagnosticSeverityError
+}
+
+// fileURI turns a filesystem path into the file:// URI form LSP locations
+// use. It's a plain prefix, not full RFC 3986 percent-encoding, which is
+// enough for the ASCII paths this compiler's own test fixtures and build
+// output use today.
+func fileURI(path string) string {
+	return "file://" + path
+}
+
+type publishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// PublishDiagnostics sends textDocument/publishDiagnostics for uri. Nothing
+// in this package calls it yet: there's no analysis pipeline that produces
+// a report.Report for an open document (report.go's own doc comment notes
+// build's single top-level error is still the only source of one), so
+// there's nothing to publish. ReportToDiagnostic above is ready for
+// whichever handler starts producing Reports per document to call this
+// with.
+func (s *Server) PublishDiagnostics(uri string, diagnostics []Diagnostic) error {
+	return s.Notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+}
@@ -0,0 +1,63 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/report"
+)
+
+func TestReportToDiagnosticConvertsSeverityRangeAndRelatedSpans(t *testing.T) {
+	r := report.Report{
+		File:     "/repo/a.fer",
+		Range:    report.Range{Start: report.Position{Line: 2, Column: 3}, End: report.Position{Line: 2, Column: 7}},
+		Severity: report.SeverityWarning,
+		Code:     report.CodeBuildFailed,
+		Message:  "unused variable",
+		Related: []report.RelatedSpan{
+			{File: "/repo/b.fer", Range: report.Range{Start: report.Position{Line: 5, Column: 1}, End: report.Position{Line: 5, Column: 2}}, Message: "declared here"},
+		},
+	}
+
+	d := ReportToDiagnostic(r)
+
+	if d.Severity != DiagnosticSeverityWarning {
+		t.Errorf("expected warning severity, got %d", d.Severity)
+	}
+	if d.Range.Start.Line != 1 || d.Range.Start.Character != 2 {
+		t.Errorf("expected the 1-based report position shifted to 0-based, got %+v", d.Range.Start)
+	}
+	if len(d.RelatedInformation) != 1 || d.RelatedInformation[0].Location.URI != "file:///repo/b.fer" {
+		t.Errorf("expected the related span converted, got %+v", d.RelatedInformation)
+	}
+	if d.Source != "ferret" {
+		t.Errorf("expected the ferret source, got %q", d.Source)
+	}
+	if len(d.Tags) != 0 {
+		t.Errorf("expected no tags without an unused-symbol analysis, got %v", d.Tags)
+	}
+}
+
+func TestPublishDiagnosticsSendsANotificationDuringServe(t *testing.T) {
+	s := New()
+	s.Handle("trigger", func(params json.RawMessage) (interface{}, error) {
+		return nil, s.PublishDiagnostics("file:///a.fer", []Diagnostic{{Message: "oops"}})
+	})
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","id":1,"method":"trigger"}`))
+	var out bytes.Buffer
+	sw := safeWriter{w: &out, mu: &sync.Mutex{}}
+	if err := s.Serve(in, sw); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	sw.mu.Lock()
+	got := out.String()
+	sw.mu.Unlock()
+	if !strings.Contains(got, `"method":"textDocument/publishDiagnostics"`) {
+		t.Errorf("expected a publishDiagnostics notification, got %q", got)
+	}
+}
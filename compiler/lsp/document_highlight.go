@@ -0,0 +1,27 @@
+package lsp
+
+import "encoding/json"
+
+// DocumentHighlightKindText is the default highlight kind when nothing
+// distinguishes a read from a write occurrence.
+const DocumentHighlightKindText = 1
+
+// DocumentHighlight is one occurrence returned by textDocument/documentHighlight.
+type DocumentHighlight struct {
+	Range Range `json:"range"`
+	Kind  int   `json:"kind,omitempty"`
+}
+
+// RegisterDocumentHighlight wires textDocument/documentHighlight. Finding
+// every occurrence of the symbol under the cursor needs the same symbol
+// resolution textDocument/references would (see RegisterReferences), which
+// this compiler doesn't have, so it always reports none.
+func RegisterDocumentHighlight(s *Server) {
+	s.Handle("textDocument/documentHighlight", func(params json.RawMessage) (interface{}, error) {
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return []DocumentHighlight{}, nil
+	})
+}
@@ -0,0 +1,39 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDocumentHighlightReturnsNoHighlightsWithoutSymbolResolution(t *testing.T) {
+	s := New()
+	RegisterDocumentHighlight(s)
+
+	params, _ := json.Marshal(TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"}})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/documentHighlight", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"result":[]`) {
+		t.Errorf("expected no highlights, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesDocumentHighlightProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterDocumentHighlight(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"documentHighlightProvider":true`) {
+		t.Errorf("expected documentHighlightProvider:true, got %q", out.String())
+	}
+}
@@ -0,0 +1,162 @@
+package lsp
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// Documents is an in-memory overlay of open documents' editor-buffer
+// contents, keyed by URI. Diagnostics and every other feature in this
+// package should read from it rather than the filesystem, so unsaved
+// edits are reflected immediately instead of only after the next save.
+//
+// There's no CompileProjectForLSP anywhere in this repo for a per-module
+// analysis cache to sit in front of: no handler in this package runs a
+// parse or a typecheck at all today, let alone on every didChange, and
+// there's no module graph to know which modules depend on an edited one.
+// The closest existing analogue is compiler/backend/cache, which hashes
+// source plus codegen flags to skip re-emitting unchanged object code —
+// but that's downstream of codegen, not a parse/typecheck cache, and isn't
+// reachable from this package. Once a didChange handler actually triggers
+// analysis, this struct (or one shaped like it, keyed by content hash
+// instead of just URI) is where a per-module cache belongs.
+type Documents struct {
+	mu    sync.Mutex
+	texts map[string]string
+}
+
+// NewDocuments returns an empty overlay.
+func NewDocuments() *Documents {
+	return &Documents{texts: map[string]string{}}
+}
+
+// Get returns the current buffer contents for uri, or ("", false) if it
+// isn't open.
+func (d *Documents) Get(uri string) (string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	text, ok := d.texts[uri]
+	return text, ok
+}
+
+// set stores text as uri's current buffer contents.
+func (d *Documents) set(uri, text string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.texts[uri] = text
+}
+
+// drop removes uri's overlay entry entirely, as opposed to set(uri, "")
+// which would leave it open with empty contents.
+func (d *Documents) drop(uri string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.texts, uri)
+}
+
+type textDocumentItem struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type didOpenParams struct {
+	TextDocument textDocumentItem `json:"textDocument"`
+}
+
+type didCloseParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+}
+
+type didChangeParams struct {
+	TextDocument   TextDocumentIdentifier           `json:"textDocument"`
+	ContentChanges []textDocumentContentChangeEvent `json:"contentChanges"`
+}
+
+// textDocumentContentChangeEvent is either a full-document replacement
+// (Range nil, Text the whole new document) or an incremental edit (Range
+// set, Text the replacement for just that span), per the spec's two
+// textDocumentContentChangeEvent shapes.
+type textDocumentContentChangeEvent struct {
+	Range *Range `json:"range,omitempty"`
+	Text  string `json:"text"`
+}
+
+// applyRangeEdit returns text with the span rng replaced by newText.
+// Positions are zero-based line/character offsets into text, per the LSP
+// spec; character counts runes, not UTF-16 code units, which is close
+// enough for ASCII Ferret source and the editors' own ASCII test fixtures
+// this server has to work with today.
+func applyRangeEdit(text string, rng Range, newText string) string {
+	lines := strings.Split(text, "\n")
+	start := offsetOf(lines, rng.Start)
+	end := offsetOf(lines, rng.End)
+	return text[:start] + newText + text[end:]
+}
+
+// offsetOf converts a Position into a byte offset into the document whose
+// lines (split on "\n", separators not included) are lines.
+func offsetOf(lines []string, pos Position) int {
+	offset := 0
+	for i := 0; i < pos.Line && i < len(lines); i++ {
+		offset += len(lines[i]) + 1 // +1 for the "\n" split removed
+	}
+	if pos.Line < len(lines) {
+		line := lines[pos.Line]
+		if pos.Character <= len(line) {
+			offset += pos.Character
+		} else {
+			offset += len(line)
+		}
+	}
+	return offset
+}
+
+// RegisterDidClose wires textDocument/didClose to drop uri's overlay entry
+// from d, so a later didOpen for the same file starts from a clean slate
+// rather than disk contents stale since the last didChange. This server
+// doesn't publish diagnostics yet (nothing analyzes a document), so there
+// are none to clear here; once it does, didClose's handler should also
+// publish an empty diagnostics list for uri so the editor doesn't keep
+// showing stale ones for a file that's no longer open.
+func RegisterDidClose(s *Server, d *Documents) {
+	s.Handle("textDocument/didClose", func(params json.RawMessage) (interface{}, error) {
+		var p didCloseParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		d.drop(p.TextDocument.URI)
+		return nil, nil
+	})
+}
+
+// RegisterDocumentSync wires textDocument/didOpen and textDocument/didChange
+// to populate d from either full-document or incremental (textDocumentSync
+// kind 2, the mode this server advertises) didChange notifications. Both
+// methods are notifications, so neither has a result.
+func RegisterDocumentSync(s *Server, d *Documents) {
+	s.Handle("textDocument/didOpen", func(params json.RawMessage) (interface{}, error) {
+		var p didOpenParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		d.set(p.TextDocument.URI, p.TextDocument.Text)
+		return nil, nil
+	})
+	s.Handle("textDocument/didChange", func(params json.RawMessage) (interface{}, error) {
+		var p didChangeParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		text, _ := d.Get(p.TextDocument.URI)
+		for _, change := range p.ContentChanges {
+			if change.Range == nil {
+				text = change.Text
+				continue
+			}
+			text = applyRangeEdit(text, *change.Range, change.Text)
+		}
+		d.set(p.TextDocument.URI, text)
+		return nil, nil
+	})
+}
@@ -0,0 +1,98 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func notify(s *Server, method string, params interface{}) (string, error) {
+	p, _ := json.Marshal(params)
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", Method: method, Params: p})
+	var out bytes.Buffer
+	err := s.Serve(strings.NewReader(frame(string(req))), &out)
+	return out.String(), err
+}
+
+func TestDidOpenStoresTheDocumentsBufferContents(t *testing.T) {
+	s := New()
+	d := NewDocuments()
+	RegisterDocumentSync(s, d)
+
+	if _, err := notify(s, "textDocument/didOpen", didOpenParams{TextDocument: textDocumentItem{URI: "file:///a.fer", Text: "fn main() {}"}}); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+
+	text, ok := d.Get("file:///a.fer")
+	if !ok || text != "fn main() {}" {
+		t.Errorf("expected the overlay to hold the opened text, got %q, %v", text, ok)
+	}
+}
+
+func TestDidChangeReplacesTheStoredText(t *testing.T) {
+	s := New()
+	d := NewDocuments()
+	RegisterDocumentSync(s, d)
+
+	notify(s, "textDocument/didOpen", didOpenParams{TextDocument: textDocumentItem{URI: "file:///a.fer", Text: "old"}})
+	notify(s, "textDocument/didChange", didChangeParams{
+		TextDocument:   TextDocumentIdentifier{URI: "file:///a.fer"},
+		ContentChanges: []textDocumentContentChangeEvent{{Text: "new"}},
+	})
+
+	text, ok := d.Get("file:///a.fer")
+	if !ok || text != "new" {
+		t.Errorf("expected didChange to replace the buffer contents, got %q, %v", text, ok)
+	}
+}
+
+func TestDidCloseDropsTheOverlayEntry(t *testing.T) {
+	s := New()
+	d := NewDocuments()
+	RegisterDocumentSync(s, d)
+	RegisterDidClose(s, d)
+
+	notify(s, "textDocument/didOpen", didOpenParams{TextDocument: textDocumentItem{URI: "file:///a.fer", Text: "fn main() {}"}})
+	notify(s, "textDocument/didClose", didCloseParams{TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"}})
+
+	if _, ok := d.Get("file:///a.fer"); ok {
+		t.Errorf("expected didClose to drop the overlay entry")
+	}
+}
+
+func TestInitializeAdvertisesIncrementalTextDocumentSyncOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterDocumentSync(s, NewDocuments())
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"textDocumentSync":2`) {
+		t.Errorf("expected textDocumentSync:2 once RegisterDocumentSync ran, got %q", out.String())
+	}
+}
+
+func TestDidChangeAppliesAnIncrementalRangeEdit(t *testing.T) {
+	s := New()
+	d := NewDocuments()
+	RegisterDocumentSync(s, d)
+
+	notify(s, "textDocument/didOpen", didOpenParams{TextDocument: textDocumentItem{URI: "file:///a.fer", Text: "line one\nline two\n"}})
+	notify(s, "textDocument/didChange", didChangeParams{
+		TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"},
+		ContentChanges: []textDocumentContentChangeEvent{{
+			Range: &Range{Start: Position{Line: 1, Character: 5}, End: Position{Line: 1, Character: 8}},
+			Text:  "TWO",
+		}},
+	})
+
+	text, _ := d.Get("file:///a.fer")
+	if text != "line one\nline TWO\n" {
+		t.Errorf("expected the range edit applied in place, got %q", text)
+	}
+}
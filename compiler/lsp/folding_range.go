@@ -0,0 +1,26 @@
+package lsp
+
+import "encoding/json"
+
+// FoldingRange is one collapsible region returned by
+// textDocument/foldingRange, per the spec.
+type FoldingRange struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+}
+
+// RegisterFoldingRange wires textDocument/foldingRange. Computing regions
+// from Block nodes, function bodies, struct literals and import groups
+// needs a parsed AST for the file (see RegisterDocumentSymbol), so it
+// always answers with no foldable regions.
+func RegisterFoldingRange(s *Server) {
+	s.Handle("textDocument/foldingRange", func(params json.RawMessage) (interface{}, error) {
+		var p struct {
+			TextDocument TextDocumentIdentifier `json:"textDocument"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return []FoldingRange{}, nil
+	})
+}
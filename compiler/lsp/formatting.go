@@ -0,0 +1,19 @@
+package lsp
+
+import "encoding/json"
+
+// RegisterFormatting wires textDocument/formatting. There's no canonical
+// formatter over the Ferret AST yet — there's no AST population at all
+// (see RegisterDocumentSymbol) — so it always answers with no edits rather
+// than reformatting nothing and claiming success.
+func RegisterFormatting(s *Server) {
+	s.Handle("textDocument/formatting", func(params json.RawMessage) (interface{}, error) {
+		var p struct {
+			TextDocument TextDocumentIdentifier `json:"textDocument"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return []TextEdit{}, nil
+	})
+}
@@ -0,0 +1,39 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFormattingReturnsNoEditsWithNoFormatter(t *testing.T) {
+	s := New()
+	RegisterFormatting(s)
+
+	params, _ := json.Marshal(map[string]interface{}{"textDocument": TextDocumentIdentifier{URI: "file:///a.fer"}})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/formatting", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"result":[]`) {
+		t.Errorf("expected no edits, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesDocumentFormattingProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterFormatting(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"documentFormattingProvider":true`) {
+		t.Errorf("expected documentFormattingProvider:true, got %q", out.String())
+	}
+}
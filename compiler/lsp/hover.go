@@ -0,0 +1,30 @@
+package lsp
+
+import "encoding/json"
+
+// MarkupContent is the hover/documentation payload shape LSP clients
+// render, per the spec.
+type MarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// Hover is the result of a textDocument/hover request.
+type Hover struct {
+	Contents MarkupContent `json:"contents"`
+}
+
+// RegisterHover wires textDocument/hover. Like RegisterDefinition, it has
+// no typecheck phase to source a resolved type, kind or declaring module
+// from yet, so it always answers "nothing to show" (a null result). See
+// RegisterDefinition's doc comment for why that's the honest answer today
+// rather than a guess.
+func RegisterHover(s *Server) {
+	s.Handle("textDocument/hover", func(params json.RawMessage) (interface{}, error) {
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,44 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHoverReturnsNullWithNoTypecheckPhase(t *testing.T) {
+	s := New()
+	RegisterHover(s)
+
+	params, _ := json.Marshal(TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"},
+		Position:     Position{Line: 0, Character: 0},
+	})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/hover", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if strings.Contains(out.String(), `"error"`) {
+		t.Errorf("expected no error for hover with no typecheck phase, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesHoverProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterHover(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"hoverProvider":true`) {
+		t.Errorf("expected hoverProvider:true once RegisterHover ran, got %q", out.String())
+	}
+}
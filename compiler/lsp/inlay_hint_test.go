@@ -0,0 +1,39 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInlayHintReturnsNoHintsWithNoTypecheckPhase(t *testing.T) {
+	s := New()
+	RegisterInlayHint(s)
+
+	params, _ := json.Marshal(map[string]interface{}{"textDocument": TextDocumentIdentifier{URI: "file:///a.fer"}, "range": Range{}})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/inlayHint", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"result":[]`) {
+		t.Errorf("expected no hints, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesInlayHintProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterInlayHint(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"inlayHintProvider":true`) {
+		t.Errorf("expected inlayHintProvider:true, got %q", out.String())
+	}
+}
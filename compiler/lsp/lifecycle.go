@@ -0,0 +1,152 @@
+package lsp
+
+import "encoding/json"
+
+// TextDocumentIdentifier identifies an open document by its URI, the way
+// every textDocument/* request and notification addresses one.
+type TextDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+// Position is a zero-based line/character offset into a document, per the
+// LSP spec.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// TextDocumentPositionParams is the common params shape for request
+// methods that ask about one position in one document (definition, hover,
+// ...).
+type TextDocumentPositionParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+}
+
+// serverCapabilities is the subset of InitializeResult.capabilities this
+// server actually advertises. Capabilities are added here as the matching
+// handler is registered; advertising one with no handler would make an
+// editor call a method the server answers with ErrMethodNotFound.
+type serverCapabilities struct {
+	DefinitionProvider         bool                   `json:"definitionProvider,omitempty"`
+	HoverProvider              bool                   `json:"hoverProvider,omitempty"`
+	CompletionProvider         *completionOptions     `json:"completionProvider,omitempty"`
+	ReferencesProvider         bool                   `json:"referencesProvider,omitempty"`
+	DocumentSymbolProvider     bool                   `json:"documentSymbolProvider,omitempty"`
+	WorkspaceSymbolProvider    bool                   `json:"workspaceSymbolProvider,omitempty"`
+	SemanticTokensProvider     *semanticTokensOptions `json:"semanticTokensProvider,omitempty"`
+	TextDocumentSync           int                    `json:"textDocumentSync,omitempty"`
+	SignatureHelpProvider      *signatureHelpOptions  `json:"signatureHelpProvider,omitempty"`
+	CodeActionProvider         bool                   `json:"codeActionProvider,omitempty"`
+	DocumentFormattingProvider bool                   `json:"documentFormattingProvider,omitempty"`
+	FoldingRangeProvider       bool                   `json:"foldingRangeProvider,omitempty"`
+	InlayHintProvider          bool                   `json:"inlayHintProvider,omitempty"`
+	TypeHierarchyProvider      bool                   `json:"typeHierarchyProvider,omitempty"`
+	DocumentHighlightProvider  bool                   `json:"documentHighlightProvider,omitempty"`
+	SelectionRangeProvider     bool                   `json:"selectionRangeProvider,omitempty"`
+	TypeDefinitionProvider     bool                   `json:"typeDefinitionProvider,omitempty"`
+	ImplementationProvider     bool                   `json:"implementationProvider,omitempty"`
+}
+
+type signatureHelpOptions struct {
+	TriggerCharacters []string `json:"triggerCharacters,omitempty"`
+}
+
+// TextDocumentSyncFull is textDocumentSync kind 1: didChange always
+// carries the whole new document.
+const TextDocumentSyncFull = 1
+
+// TextDocumentSyncIncremental is textDocumentSync kind 2: didChange may
+// carry just the edited range, so large files don't need a full resend on
+// every keystroke.
+const TextDocumentSyncIncremental = 2
+
+// completionOptions advertises what triggers completion, per the spec.
+type completionOptions struct {
+	TriggerCharacters []string `json:"triggerCharacters,omitempty"`
+}
+
+type initializeResult struct {
+	Capabilities serverCapabilities `json:"capabilities"`
+}
+
+// RegisterLifecycle wires the initialize/shutdown/exit handlers every LSP
+// session needs regardless of which language features it supports.
+// initialize reports capabilities reflecting whichever other handlers are
+// registered on s via Handle at the time initialize is actually dispatched,
+// so RegisterLifecycle can be called before or after the feature handlers.
+func RegisterLifecycle(s *Server) {
+	s.Handle("initialize", func(params json.RawMessage) (interface{}, error) {
+		if len(params) > 0 {
+			var p struct {
+				RootURI               *string           `json:"rootUri"`
+				WorkspaceFolders      []WorkspaceFolder `json:"workspaceFolders"`
+				InitializationOptions json.RawMessage   `json:"initializationOptions"`
+				Trace                 string            `json:"trace"`
+			}
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+			switch {
+			case len(p.WorkspaceFolders) > 0:
+				s.setWorkspaceFolders(p.WorkspaceFolders)
+			case p.RootURI != nil:
+				// Pre-3.6 clients send a single rootUri instead of
+				// workspaceFolders; treat it as a one-folder workspace.
+				s.setWorkspaceFolders([]WorkspaceFolder{{URI: *p.RootURI}})
+			}
+			if len(p.InitializationOptions) > 0 {
+				s.setSettings(p.InitializationOptions)
+			}
+			if p.Trace != "" {
+				s.setTrace(p.Trace)
+			}
+		}
+
+		caps := serverCapabilities{
+			DefinitionProvider: s.handlers["textDocument/definition"] != nil,
+			HoverProvider:      s.handlers["textDocument/hover"] != nil,
+		}
+		if s.handlers["textDocument/completion"] != nil {
+			caps.CompletionProvider = &completionOptions{TriggerCharacters: []string{"::"}}
+		}
+		caps.ReferencesProvider = s.handlers["textDocument/references"] != nil
+		caps.DocumentSymbolProvider = s.handlers["textDocument/documentSymbol"] != nil
+		caps.WorkspaceSymbolProvider = s.handlers["workspace/symbol"] != nil
+		if s.handlers["textDocument/didOpen"] != nil {
+			caps.TextDocumentSync = TextDocumentSyncIncremental
+		}
+		if s.handlers["textDocument/signatureHelp"] != nil {
+			caps.SignatureHelpProvider = &signatureHelpOptions{TriggerCharacters: []string{"("}}
+		}
+		caps.CodeActionProvider = s.handlers["textDocument/codeAction"] != nil
+		caps.DocumentFormattingProvider = s.handlers["textDocument/formatting"] != nil
+		caps.FoldingRangeProvider = s.handlers["textDocument/foldingRange"] != nil
+		caps.InlayHintProvider = s.handlers["textDocument/inlayHint"] != nil
+		caps.TypeHierarchyProvider = s.handlers["textDocument/prepareTypeHierarchy"] != nil
+		caps.DocumentHighlightProvider = s.handlers["textDocument/documentHighlight"] != nil
+		caps.SelectionRangeProvider = s.handlers["textDocument/selectionRange"] != nil
+		caps.TypeDefinitionProvider = s.handlers["textDocument/typeDefinition"] != nil
+		caps.ImplementationProvider = s.handlers["textDocument/implementation"] != nil
+		if s.handlers["textDocument/semanticTokens/full"] != nil {
+			caps.SemanticTokensProvider = &semanticTokensOptions{
+				Legend: semanticTokensLegend{TokenTypes: semanticTokenTypes},
+				Full:   true,
+			}
+		}
+		return initializeResult{Capabilities: caps}, nil
+	})
+	s.Handle("shutdown", func(params json.RawMessage) (interface{}, error) {
+		s.setShutdown()
+		return nil, nil
+	})
+	// exit is handled by Server.Serve itself, which ends the session as
+	// soon as it's dispatched; registering a no-op handler here just lets
+	// it be dispatched rather than answered with ErrMethodNotFound first.
+	// Serve also returns as soon as stdin reaches EOF even with no exit —
+	// a client that disconnects without a clean shutdown/exit still ends
+	// the session rather than leaving it blocked reading forever.
+	s.Handle("exit", func(params json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+}
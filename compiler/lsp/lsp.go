@@ -0,0 +1,366 @@
+// Package lsp implements the wire protocol for a Language Server Protocol
+// server: JSON-RPC 2.0 framed over stdio, request dispatch, and the
+// initialize/shutdown lifecycle. It does not implement language features
+// itself — handlers for those (go-to-definition, hover, completion, ...)
+// are registered by the caller and, until the frontend has a symbol table
+// to query, mostly report "no result" the way a real but not-yet-capable
+// server would.
+//
+// $/cancelRequest is honored for real: a cancelled request's handler is
+// skipped and answered with ErrRequestCancelled. There's no debounce window
+// or per-URI analysis coalescing on top of that, because didChange
+// (RegisterDocumentSync) only updates an in-memory overlay today — nothing
+// runs a project compile in response to an edit yet for a later edit to
+// need to cancel or coalesce with.
+//
+// $/setTrace and window/logMessage (see trace.go) let a client opt into
+// protocol tracing per session instead of this server deciding on its own
+// whether to print anything to stderr.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Request is a JSON-RPC 2.0 request or notification. A notification is a
+// Request with no ID and gets no Response.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is the JSON-RPC 2.0 reply to a Request that carried an ID.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *ResponseError  `json:"error,omitempty"`
+}
+
+// ResponseError is a JSON-RPC 2.0 error object.
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Standard JSON-RPC error codes used by Server.
+const (
+	ErrParse          = -32700
+	ErrMethodNotFound = -32601
+	ErrInternal       = -32603
+	// ErrRequestCancelled is the LSP-specific code for a request answered
+	// after the client asked to cancel it via $/cancelRequest.
+	ErrRequestCancelled = -32800
+	// ErrInvalidRequest answers any request but exit sent after shutdown,
+	// per the spec's shutdown/exit lifecycle.
+	ErrInvalidRequest = -32600
+)
+
+// Handler responds to a single request or notification's params, returning
+// the value to place in Response.Result. Handler is never called for a
+// notification's result, but is still called for its side effects.
+type Handler func(params json.RawMessage) (interface{}, error)
+
+// Server dispatches framed JSON-RPC messages read from stdio to Handlers
+// registered by method name.
+type Server struct {
+	handlers map[string]Handler
+
+	cancelMu  sync.Mutex
+	cancelled map[string]bool
+
+	workers  chan struct{}
+	writeMu  sync.Mutex
+	writer   io.Writer
+	inFlight sync.WaitGroup
+
+	writeErrOnce sync.Once
+	writeErr     error
+
+	workspaceMu      sync.Mutex
+	workspaceFolders []WorkspaceFolder
+
+	settingsMu sync.Mutex
+	settings   json.RawMessage
+
+	shutdownMu sync.Mutex
+	shutdown   bool
+
+	traceMu sync.Mutex
+	trace   string
+}
+
+// setShutdown records that the client has sent a shutdown request, so
+// Serve can start rejecting everything but exit, per the spec's
+// shutdown/exit lifecycle.
+func (s *Server) setShutdown() {
+	s.shutdownMu.Lock()
+	defer s.shutdownMu.Unlock()
+	s.shutdown = true
+}
+
+func (s *Server) isShutdown() bool {
+	s.shutdownMu.Lock()
+	defer s.shutdownMu.Unlock()
+	return s.shutdown
+}
+
+// Options configures a Server built by NewWithOptions.
+type Options struct {
+	// Workers is how many requests may have their handlers running at once.
+	// Zero means runtime.NumCPU(). Notifications (didOpen, didChange, exit,
+	// ...) always run inline on the read loop, in wire order, regardless of
+	// this setting, since they mutate session state (the document overlay,
+	// the cancelled set) that later messages depend on seeing in order.
+	Workers int
+}
+
+// New returns a Server with no methods registered; the caller wires up
+// initialize, shutdown and whichever language features it supports via
+// Handle.
+func New() *Server {
+	return NewWithOptions(Options{})
+}
+
+// NewWithOptions is New with a configurable worker pool size.
+func NewWithOptions(opts Options) *Server {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return &Server{
+		handlers:  map[string]Handler{},
+		cancelled: map[string]bool{},
+		workers:   make(chan struct{}, workers),
+	}
+}
+
+// requestKey turns a request's raw JSON id into a comparable map key. LSP
+// ids are either numbers or strings; comparing the raw bytes is enough to
+// match a $/cancelRequest's id against the request it refers to.
+func requestKey(id json.RawMessage) string {
+	return string(id)
+}
+
+// cancel marks id as cancelled. A $/cancelRequest notification can arrive
+// before the request it targets has even been read off the wire, so the
+// mark is checked by dispatch regardless of ordering.
+func (s *Server) cancel(id json.RawMessage) {
+	s.cancelMu.Lock()
+	defer s.cancelMu.Unlock()
+	s.cancelled[requestKey(id)] = true
+}
+
+// popCancelled reports whether id was cancelled and clears the mark, so the
+// set doesn't grow for the life of the session.
+func (s *Server) popCancelled(id json.RawMessage) bool {
+	s.cancelMu.Lock()
+	defer s.cancelMu.Unlock()
+	key := requestKey(id)
+	cancelled := s.cancelled[key]
+	delete(s.cancelled, key)
+	return cancelled
+}
+
+// Handle registers h to answer requests and notifications for method,
+// replacing any handler previously registered for it.
+func (s *Server) Handle(method string, h Handler) {
+	s.handlers[method] = h
+}
+
+// Serve reads framed JSON-RPC messages from r until r is exhausted or an
+// "exit" notification is dispatched, writing a framed Response to w for
+// every message that carried an ID. A method with no registered Handle is
+// answered with an ErrMethodNotFound error rather than closing the
+// connection, so one unimplemented request doesn't take down the session.
+//
+// Requests (messages with an id) run their handler on a goroutine drawn
+// from the worker pool sized by Options.Workers, so a slow one (a
+// diagnostics pass, say) can't stall a quick one, such as hover or
+// shutdown, that a client sends right behind it. Responses are written
+// through a mutex-guarded writer since they can then complete out of
+// order. Notifications run inline on the read loop, before the next
+// message is even read, because didOpen/didChange/didClose/$/cancelRequest
+// all mutate state that later messages need to observe in the order it
+// changed.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.writeMu.Lock()
+	s.writer = w
+	s.writeMu.Unlock()
+
+	br := bufio.NewReader(r)
+	for {
+		msg, err := readMessage(br)
+		if err == io.EOF {
+			s.inFlight.Wait()
+			return s.writeErr
+		}
+		if err != nil {
+			s.inFlight.Wait()
+			return err
+		}
+
+		var req Request
+		if err := json.Unmarshal(msg, &req); err != nil {
+			if werr := s.writeResponse(w, Response{JSONRPC: "2.0", Error: &ResponseError{Code: ErrParse, Message: err.Error()}}); werr != nil {
+				s.inFlight.Wait()
+				return werr
+			}
+			continue
+		}
+
+		// $/cancelRequest is a notification, not a language feature, so it's
+		// handled here rather than through the Handle registry: it has to
+		// take effect before a request running on another worker checks it.
+		if req.Method == "$/cancelRequest" {
+			var p struct {
+				ID json.RawMessage `json:"id"`
+			}
+			if err := json.Unmarshal(req.Params, &p); err == nil {
+				s.cancel(p.ID)
+			}
+			continue
+		}
+
+		if len(req.ID) == 0 {
+			// Notification: run inline, in wire order, with no response. Per
+			// the shutdown/exit lifecycle, everything but exit is dropped
+			// once the client has asked to shut down.
+			if req.Method != "exit" && s.isShutdown() {
+				continue
+			}
+			_, _ = s.dispatch(req)
+			if req.Method == "exit" {
+				s.inFlight.Wait()
+				return s.writeErr
+			}
+			continue
+		}
+
+		if s.isShutdown() {
+			if werr := s.writeResponse(w, Response{JSONRPC: "2.0", ID: req.ID, Error: &ResponseError{Code: ErrInvalidRequest, Message: "server is shutting down"}}); werr != nil {
+				s.inFlight.Wait()
+				return werr
+			}
+			continue
+		}
+
+		if req.Method == "shutdown" {
+			// Marked synchronously, here on the read loop, rather than left
+			// to the "shutdown" Handler running on a worker goroutine: a
+			// client's very next message can otherwise be read and checked
+			// against isShutdown() before that goroutine gets scheduled.
+			s.setShutdown()
+		}
+
+		if s.popCancelled(req.ID) {
+			if werr := s.writeResponse(w, Response{JSONRPC: "2.0", ID: req.ID, Error: &ResponseError{Code: ErrRequestCancelled, Message: "request cancelled"}}); werr != nil {
+				s.inFlight.Wait()
+				return werr
+			}
+			continue
+		}
+
+		s.inFlight.Add(1)
+		s.workers <- struct{}{}
+		go func(req Request) {
+			defer s.inFlight.Done()
+			defer func() { <-s.workers }()
+
+			result, herr := s.dispatch(req)
+			resp := Response{JSONRPC: "2.0", ID: req.ID, Result: result}
+			if herr != nil {
+				resp.Result = nil
+				resp.Error = &ResponseError{Code: ErrInternal, Message: herr.Error()}
+			}
+			if werr := s.writeResponse(w, resp); werr != nil {
+				s.writeErrOnce.Do(func() { s.writeErr = werr })
+			}
+		}(req)
+	}
+}
+
+// writeResponse serializes concurrent writers onto w, since Serve answers
+// requests from a worker pool whose goroutines can finish in any order.
+func (s *Server) writeResponse(w io.Writer, resp Response) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return writeMessage(w, resp)
+}
+
+// Notify sends a server-initiated notification to the client, such as
+// $/progress or window/logMessage — a message with a method and params but
+// no id, and so no Response to wait for. It uses the same writer and mutex
+// as Serve's responses, so a notification is never interleaved mid-message
+// with one. Calling Notify before Serve has been invoked, or after it has
+// returned, is a no-op; there's no client connection to write to.
+func (s *Server) Notify(method string, params interface{}) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if s.writer == nil {
+		return nil
+	}
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return writeMessage(s.writer, Request{JSONRPC: "2.0", Method: method, Params: raw})
+}
+
+func (s *Server) dispatch(req Request) (interface{}, error) {
+	h, ok := s.handlers[req.Method]
+	if !ok {
+		return nil, fmt.Errorf("method not found: %s", req.Method)
+	}
+	return h(req.Params)
+}
+
+// readMessage reads one Content-Length-framed LSP message from br and
+// returns its body.
+func readMessage(br *bufio.Reader) ([]byte, error) {
+	var length int
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "Content-Length:") {
+			n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "Content-Length:")))
+			if err != nil {
+				return nil, fmt.Errorf("lsp: invalid Content-Length header %q: %w", line, err)
+			}
+			length = n
+		}
+	}
+	if length == 0 {
+		return nil, fmt.Errorf("lsp: message had no Content-Length header")
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(br, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// writeMessage writes v as a Content-Length-framed LSP message to w.
+func writeMessage(w io.Writer, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	return err
+}
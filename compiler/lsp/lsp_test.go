@@ -0,0 +1,215 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func frame(body string) string {
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func TestServeAnswersARegisteredMethod(t *testing.T) {
+	s := New()
+	s.Handle("ping", func(params json.RawMessage) (interface{}, error) {
+		return "pong", nil
+	})
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+	var out bytes.Buffer
+	if err := s.Serve(in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"result":"pong"`) {
+		t.Errorf("expected a pong result, got %q", out.String())
+	}
+}
+
+func TestServeAnswersAnUnknownMethodWithMethodNotFound(t *testing.T) {
+	s := New()
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","id":1,"method":"nope"}`))
+	var out bytes.Buffer
+	if err := s.Serve(in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"code":-32603`) {
+		t.Errorf("expected an internal error for the unknown method, got %q", out.String())
+	}
+}
+
+func TestServeSendsNoResponseToANotification(t *testing.T) {
+	called := false
+	s := New()
+	s.Handle("didOpen", func(params json.RawMessage) (interface{}, error) {
+		called = true
+		return nil, nil
+	})
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","method":"didOpen"}`))
+	var out bytes.Buffer
+	if err := s.Serve(in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !called {
+		t.Errorf("expected the notification handler to run")
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no response written for a notification, got %q", out.String())
+	}
+}
+
+func TestServeAnswersACancelledRequestWithRequestCancelled(t *testing.T) {
+	called := false
+	s := New()
+	s.Handle("slow", func(params json.RawMessage) (interface{}, error) {
+		called = true
+		return "done", nil
+	})
+
+	in := strings.NewReader(
+		frame(`{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":1}}`) +
+			frame(`{"jsonrpc":"2.0","id":1,"method":"slow"}`),
+	)
+	var out bytes.Buffer
+	if err := s.Serve(in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if called {
+		t.Errorf("expected the cancelled request's handler not to run")
+	}
+	if !strings.Contains(out.String(), `"code":-32800`) {
+		t.Errorf("expected a RequestCancelled error, got %q", out.String())
+	}
+}
+
+func TestServeRunsAnUncancelledRequestNormally(t *testing.T) {
+	s := New()
+	s.Handle("slow", func(params json.RawMessage) (interface{}, error) {
+		return "done", nil
+	})
+
+	in := strings.NewReader(
+		frame(`{"jsonrpc":"2.0","method":"$/cancelRequest","params":{"id":2}}`) +
+			frame(`{"jsonrpc":"2.0","id":1,"method":"slow"}`),
+	)
+	var out bytes.Buffer
+	if err := s.Serve(in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"result":"done"`) {
+		t.Errorf("expected the uncancelled request to run normally, got %q", out.String())
+	}
+}
+
+func TestServeAnswersAQuickRequestWhileASlowOneIsStillRunning(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	s := NewWithOptions(Options{Workers: 2})
+	s.Handle("slow", func(params json.RawMessage) (interface{}, error) {
+		close(started)
+		<-release
+		return "slow-done", nil
+	})
+	s.Handle("quick", func(params json.RawMessage) (interface{}, error) {
+		return "quick-done", nil
+	})
+
+	in := strings.NewReader(
+		frame(`{"jsonrpc":"2.0","id":1,"method":"slow"}`) +
+			frame(`{"jsonrpc":"2.0","id":2,"method":"quick"}`) +
+			frame(`{"jsonrpc":"2.0","method":"exit"}`),
+	)
+	var out bytes.Buffer
+	var mu sync.Mutex
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(in, safeWriter{w: &out, mu: &mu})
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("slow handler never started")
+	}
+
+	mu.Lock()
+	quickAnsweredEarly := strings.Contains(out.String(), `"result":"quick-done"`)
+	mu.Unlock()
+	if !quickAnsweredEarly {
+		t.Errorf("expected the quick request to be answered while the slow one was still blocked")
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"result":"slow-done"`) {
+		t.Errorf("expected the slow request to eventually be answered, got %q", out.String())
+	}
+}
+
+// safeWriter guards concurrent writes for tests that poll the buffer while
+// Serve's worker goroutines may still be writing to it.
+type safeWriter struct {
+	w  *bytes.Buffer
+	mu *sync.Mutex
+}
+
+func (s safeWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+func TestServeRejectsRequestsAfterShutdown(t *testing.T) {
+	called := false
+	s := New()
+	RegisterLifecycle(s)
+	s.Handle("ping", func(params json.RawMessage) (interface{}, error) {
+		called = true
+		return "pong", nil
+	})
+
+	in := strings.NewReader(
+		frame(`{"jsonrpc":"2.0","id":1,"method":"shutdown"}`) +
+			frame(`{"jsonrpc":"2.0","id":2,"method":"ping"}`) +
+			frame(`{"jsonrpc":"2.0","method":"exit"}`),
+	)
+	var out bytes.Buffer
+	if err := s.Serve(in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if called {
+		t.Errorf("expected ping to be rejected after shutdown, not dispatched")
+	}
+	if !strings.Contains(out.String(), `"code":-32600`) {
+		t.Errorf("expected an InvalidRequest error for the post-shutdown request, got %q", out.String())
+	}
+}
+
+func TestServeStopsOnExit(t *testing.T) {
+	s := New()
+	RegisterLifecycle(s)
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","method":"exit"}`) + frame(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+	var out bytes.Buffer
+	if err := s.Serve(in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected Serve to stop at exit without reaching the later message, got %q", out.String())
+	}
+}
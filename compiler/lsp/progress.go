@@ -0,0 +1,37 @@
+package lsp
+
+// WorkDoneProgressKindBegin, ...Report and ...End are the three Kind values
+// a $/progress notification's value carries, per the spec's
+// WorkDoneProgressBegin/Report/End shapes.
+const (
+	WorkDoneProgressKindBegin  = "begin"
+	WorkDoneProgressKindReport = "report"
+	WorkDoneProgressKindEnd    = "end"
+)
+
+// WorkDoneProgress is a $/progress notification's value for the three
+// kinds above combined into one struct; which fields apply depends on
+// Kind, matching how the spec defines three separate shapes that only
+// ever appear one at a time.
+type WorkDoneProgress struct {
+	Kind        string `json:"kind"`
+	Title       string `json:"title,omitempty"`
+	Cancellable bool   `json:"cancellable,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Percentage  int    `json:"percentage,omitempty"`
+}
+
+type progressParams struct {
+	Token string           `json:"token"`
+	Value WorkDoneProgress `json:"value"`
+}
+
+// Progress sends a $/progress notification for the given token. Nothing in
+// this package calls it yet — there's no CompileProjectForLSP or any other
+// parse/resolve/typecheck loop over a project's modules for it to report
+// on (see the note on Documents in documents.go) — but the plumbing to
+// actually get a notification onto the wire, independent of any request's
+// response, now exists for whichever handler needs it once that loop does.
+func (s *Server) Progress(token string, value WorkDoneProgress) error {
+	return s.Notify("$/progress", progressParams{Token: token, Value: value})
+}
@@ -0,0 +1,44 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestProgressSendsAProgressNotificationDuringServe(t *testing.T) {
+	s := New()
+	s.Handle("trigger", func(params json.RawMessage) (interface{}, error) {
+		if err := s.Progress("token-1", WorkDoneProgress{Kind: WorkDoneProgressKindBegin, Title: "Analyzing"}); err != nil {
+			return nil, err
+		}
+		return "ok", nil
+	})
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","id":1,"method":"trigger"}`))
+	var out bytes.Buffer
+	sw := safeWriter{w: &out, mu: &sync.Mutex{}}
+	if err := s.Serve(in, sw); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	sw.mu.Lock()
+	got := out.String()
+	sw.mu.Unlock()
+
+	if !strings.Contains(got, `"method":"$/progress"`) {
+		t.Errorf("expected a $/progress notification, got %q", got)
+	}
+	if !strings.Contains(got, `"kind":"begin"`) {
+		t.Errorf("expected the begin notification's kind, got %q", got)
+	}
+}
+
+func TestNotifyBeforeServeIsANoOp(t *testing.T) {
+	s := New()
+	if err := s.Progress("token-1", WorkDoneProgress{Kind: WorkDoneProgressKindEnd}); err != nil {
+		t.Fatalf("Progress before Serve: %v", err)
+	}
+}
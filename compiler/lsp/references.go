@@ -0,0 +1,26 @@
+package lsp
+
+import "encoding/json"
+
+// ReferenceParams is textDocument/references' params: a position plus the
+// include-declaration flag the spec defines.
+type ReferenceParams struct {
+	TextDocumentPositionParams
+	Context struct {
+		IncludeDeclaration bool `json:"includeDeclaration"`
+	} `json:"context"`
+}
+
+// RegisterReferences wires textDocument/references. Building the
+// cross-module reference index this needs requires a resolver phase that
+// doesn't exist yet (see RegisterDefinition), so it always answers with no
+// references found rather than guessing.
+func RegisterReferences(s *Server) {
+	s.Handle("textDocument/references", func(params json.RawMessage) (interface{}, error) {
+		var p ReferenceParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return []Location{}, nil
+	})
+}
@@ -0,0 +1,46 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReferencesReturnsAnEmptyListWithNoReferenceIndex(t *testing.T) {
+	s := New()
+	RegisterReferences(s)
+
+	params, _ := json.Marshal(ReferenceParams{
+		TextDocumentPositionParams: TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"},
+			Position:     Position{Line: 0, Character: 0},
+		},
+	})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/references", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"result":[]`) {
+		t.Errorf("expected an empty result array, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesReferencesProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterReferences(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"referencesProvider":true`) {
+		t.Errorf("expected referencesProvider:true once RegisterReferences ran, got %q", out.String())
+	}
+}
@@ -0,0 +1,41 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RenameParams is textDocument/rename's params, per the spec.
+type RenameParams struct {
+	TextDocumentPositionParams
+	NewName string `json:"newName"`
+}
+
+// WorkspaceEdit is the result of a successful rename: the set of text
+// edits to apply, keyed by document URI.
+type WorkspaceEdit struct {
+	Changes map[string][]TextEdit `json:"changes,omitempty"`
+}
+
+// TextEdit replaces the text in Range with NewText, per the spec.
+type TextEdit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
+
+// RegisterRename wires textDocument/rename. Computing every occurrence of
+// a symbol (see RegisterReferences) and checking the new name doesn't
+// collide in any affected scope both need a reference index and a symbol
+// table that don't exist yet. Answering with an empty WorkspaceEdit would
+// look to the editor like the rename ran and touched nothing, which is
+// actively misleading, so this reports an error instead of silently doing
+// nothing.
+func RegisterRename(s *Server) {
+	s.Handle("textDocument/rename", func(params json.RawMessage) (interface{}, error) {
+		var p RenameParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("rename is not supported yet: no reference index to find every occurrence of the symbol")
+	})
+}
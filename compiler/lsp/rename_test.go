@@ -0,0 +1,47 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenameReportsAnErrorRatherThanAnEmptyEdit(t *testing.T) {
+	s := New()
+	RegisterRename(s)
+
+	params, _ := json.Marshal(RenameParams{
+		TextDocumentPositionParams: TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"},
+			Position:     Position{Line: 0, Character: 0},
+		},
+		NewName: "renamed",
+	})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/rename", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"error"`) {
+		t.Errorf("expected rename to report an error rather than a silent no-op edit, got %q", out.String())
+	}
+}
+
+func TestInitializeDoesNotAdvertiseRenameProvider(t *testing.T) {
+	s := New()
+	RegisterRename(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if strings.Contains(out.String(), "renameProvider") {
+		t.Errorf("expected no renameProvider capability since rename always errors, got %q", out.String())
+	}
+}
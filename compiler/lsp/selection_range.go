@@ -0,0 +1,33 @@
+package lsp
+
+import "encoding/json"
+
+// SelectionRange is one entry of textDocument/selectionRange's result: a
+// range plus, recursively, the next-larger range it expands into, per the
+// spec. Parent is nil for the outermost range this server would return.
+type SelectionRange struct {
+	Range  Range           `json:"range"`
+	Parent *SelectionRange `json:"parent,omitempty"`
+}
+
+type selectionRangeParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Positions    []Position             `json:"positions"`
+}
+
+// RegisterSelectionRange wires textDocument/selectionRange. Expanding a
+// cursor position outward one syntax node at a time needs AST node
+// locations to walk, which this server has no way to get at: there's no
+// parse tree kept anywhere a handler in this package could reach (see the
+// note on Documents in documents.go about there being no analysis pipeline
+// at all), so it reports no range for every position rather than guess at
+// node boundaries from the raw text.
+func RegisterSelectionRange(s *Server) {
+	s.Handle("textDocument/selectionRange", func(params json.RawMessage) (interface{}, error) {
+		var p selectionRangeParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
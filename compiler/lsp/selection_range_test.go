@@ -0,0 +1,42 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSelectionRangeReturnsNoRangesWithoutAParseTree(t *testing.T) {
+	s := New()
+	RegisterSelectionRange(s)
+
+	params, _ := json.Marshal(selectionRangeParams{
+		TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"},
+		Positions:    []Position{{Line: 0, Character: 0}},
+	})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/selectionRange", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if strings.Contains(out.String(), `"error"`) {
+		t.Errorf("expected a null result, not an error, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesSelectionRangeProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterSelectionRange(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"selectionRangeProvider":true`) {
+		t.Errorf("expected selectionRangeProvider:true, got %q", out.String())
+	}
+}
@@ -0,0 +1,47 @@
+package lsp
+
+import "encoding/json"
+
+// semanticTokenTypes is the legend textDocument/semanticTokens/full's
+// Data array would index into, per the spec. It lists the categories this
+// server could eventually distinguish (user types, parameters, constants,
+// module aliases — see RegisterSemanticTokens) even though none are
+// produced yet.
+var semanticTokenTypes = []string{"type", "parameter", "variable", "namespace"}
+
+// SemanticTokens is the result of a textDocument/semanticTokens/full
+// request, per the spec: Data is a flat array of relative-position-encoded
+// token runs.
+type SemanticTokens struct {
+	Data []int `json:"data"`
+}
+
+// semanticTokensLegend advertises tokenTypes/tokenModifiers for the
+// semanticTokensProvider capability.
+type semanticTokensLegend struct {
+	TokenTypes     []string `json:"tokenTypes"`
+	TokenModifiers []string `json:"tokenModifiers"`
+}
+
+type semanticTokensOptions struct {
+	Legend semanticTokensLegend `json:"legend"`
+	Full   bool                 `json:"full"`
+}
+
+// RegisterSemanticTokens wires textDocument/semanticTokens/full. Building
+// its Data array needs lexer tokens plus resolver information to tell a
+// user type or module alias from a plain identifier, and this compiler has
+// neither yet (see the frontend TODO in compiler/cmd/build.go), so it
+// always answers with no tokens — a TextMate grammar is still what an
+// editor falls back to for highlighting until then.
+func RegisterSemanticTokens(s *Server) {
+	s.Handle("textDocument/semanticTokens/full", func(params json.RawMessage) (interface{}, error) {
+		var p struct {
+			TextDocument TextDocumentIdentifier `json:"textDocument"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return SemanticTokens{Data: []int{}}, nil
+	})
+}
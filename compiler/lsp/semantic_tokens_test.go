@@ -0,0 +1,41 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSemanticTokensReturnsNoDataWithNoResolver(t *testing.T) {
+	s := New()
+	RegisterSemanticTokens(s)
+
+	params, _ := json.Marshal(map[string]interface{}{"textDocument": TextDocumentIdentifier{URI: "file:///a.fer"}})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/semanticTokens/full", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"data":[]`) {
+		t.Errorf("expected an empty data array, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesSemanticTokensLegendOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterSemanticTokens(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"tokenTypes":["type","parameter","variable","namespace"]`) {
+		t.Errorf("expected the token type legend once RegisterSemanticTokens ran, got %q", out.String())
+	}
+}
@@ -0,0 +1,17 @@
+package lsp
+
+import "encoding/json"
+
+// RegisterSignatureHelp wires textDocument/signatureHelp. Resolving the
+// call expression under the cursor to a FunctionType needs a typecheck
+// phase this compiler doesn't have yet (see RegisterHover), so it always
+// answers with no signature information rather than a guess.
+func RegisterSignatureHelp(s *Server) {
+	s.Handle("textDocument/signatureHelp", func(params json.RawMessage) (interface{}, error) {
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
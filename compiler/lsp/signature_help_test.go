@@ -0,0 +1,39 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSignatureHelpReturnsNullWithNoTypecheckPhase(t *testing.T) {
+	s := New()
+	RegisterSignatureHelp(s)
+
+	params, _ := json.Marshal(TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"}})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/signatureHelp", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if strings.Contains(out.String(), `"error"`) {
+		t.Errorf("expected no error, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesSignatureHelpProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterSignatureHelp(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"signatureHelpProvider"`) {
+		t.Errorf("expected signatureHelpProvider once RegisterSignatureHelp ran, got %q", out.String())
+	}
+}
@@ -0,0 +1,34 @@
+package lsp
+
+import "encoding/json"
+
+// SymbolKind mirrors the subset of the LSP's SymbolKind enum this server
+// would emit once it has something to report.
+const (
+	SymbolKindFunction = 12
+)
+
+// DocumentSymbol is one entry in a textDocument/documentSymbol response,
+// per the spec.
+type DocumentSymbol struct {
+	Name  string `json:"name"`
+	Kind  int    `json:"kind"`
+	Range Range  `json:"range"`
+}
+
+// RegisterDocumentSymbol wires textDocument/documentSymbol. An outline
+// needs a parsed AST for the file to walk — functions, types, methods and
+// top-level variables — and, per the build() TODO in compiler/cmd, that
+// doesn't exist yet for any file. It always answers with an empty outline
+// rather than a guess.
+func RegisterDocumentSymbol(s *Server) {
+	s.Handle("textDocument/documentSymbol", func(params json.RawMessage) (interface{}, error) {
+		var p struct {
+			TextDocument TextDocumentIdentifier `json:"textDocument"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return []DocumentSymbol{}, nil
+	})
+}
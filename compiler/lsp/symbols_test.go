@@ -0,0 +1,41 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDocumentSymbolReturnsAnEmptyOutlineWithNoAST(t *testing.T) {
+	s := New()
+	RegisterDocumentSymbol(s)
+
+	params, _ := json.Marshal(map[string]interface{}{"textDocument": TextDocumentIdentifier{URI: "file:///a.fer"}})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/documentSymbol", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"result":[]`) {
+		t.Errorf("expected an empty outline, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesDocumentSymbolProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterDocumentSymbol(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"documentSymbolProvider":true`) {
+		t.Errorf("expected documentSymbolProvider:true once RegisterDocumentSymbol ran, got %q", out.String())
+	}
+}
@@ -0,0 +1,91 @@
+package lsp
+
+import "encoding/json"
+
+// Trace values, per the spec's TraceValue: how much detail the client
+// wants back via $/logTrace. TraceOff is the default until initialize or
+// $/setTrace says otherwise.
+const (
+	TraceOff      = "off"
+	TraceMessages = "messages"
+	TraceVerbose  = "verbose"
+)
+
+// setTrace records the client's requested trace level. It's a method on
+// Server, not a separate Register*, because initialize and $/setTrace both
+// need to set the same field and neither owns it exclusively the way
+// workspaceFolders belongs to initialize alone.
+func (s *Server) setTrace(value string) {
+	s.traceMu.Lock()
+	defer s.traceMu.Unlock()
+	s.trace = value
+}
+
+// Trace returns the client's current trace level, TraceOff until set.
+func (s *Server) Trace() string {
+	s.traceMu.Lock()
+	defer s.traceMu.Unlock()
+	if s.trace == "" {
+		return TraceOff
+	}
+	return s.trace
+}
+
+// logTraceParams is the params shape for $/logTrace, per the spec.
+type logTraceParams struct {
+	Message string `json:"message"`
+	Verbose string `json:"verbose,omitempty"`
+}
+
+// LogTrace sends a $/logTrace notification carrying message, unless the
+// client's trace level is TraceOff. verbose is extra detail (timings,
+// request params) attached only when the client asked for TraceVerbose,
+// so a client that only wants TraceMessages isn't sent detail it didn't
+// request.
+func (s *Server) LogTrace(message, verbose string) error {
+	switch s.Trace() {
+	case TraceOff:
+		return nil
+	case TraceVerbose:
+		return s.Notify("$/logTrace", logTraceParams{Message: message, Verbose: verbose})
+	default:
+		return s.Notify("$/logTrace", logTraceParams{Message: message})
+	}
+}
+
+// MessageType values for window/logMessage and window/showMessage, per the
+// spec's MessageType.
+const (
+	MessageTypeError   = 1
+	MessageTypeWarning = 2
+	MessageTypeInfo    = 3
+	MessageTypeLog     = 4
+)
+
+type logMessageParams struct {
+	Type    int    `json:"type"`
+	Message string `json:"message"`
+}
+
+// LogMessage sends a window/logMessage notification. Unlike LogTrace, this
+// isn't gated on the client's trace level — window/logMessage is for
+// events worth a client showing in its output panel regardless of trace
+// verbosity, such as a failed analysis pass.
+func (s *Server) LogMessage(typ int, message string) error {
+	return s.Notify("window/logMessage", logMessageParams{Type: typ, Message: message})
+}
+
+// RegisterTrace wires $/setTrace, letting a client change its trace level
+// after initialize instead of only setting it once up front.
+func RegisterTrace(s *Server) {
+	s.Handle("$/setTrace", func(params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Value string `json:"value"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		s.setTrace(p.Value)
+		return nil, nil
+	})
+}
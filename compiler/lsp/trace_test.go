@@ -0,0 +1,135 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTraceStartsOff(t *testing.T) {
+	s := New()
+	if got := s.Trace(); got != TraceOff {
+		t.Errorf("expected TraceOff before any setTrace, got %q", got)
+	}
+}
+
+func TestSetTraceUpdatesTheLevel(t *testing.T) {
+	s := New()
+	RegisterTrace(s)
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","method":"$/setTrace","params":{"value":"verbose"}}`))
+	var out bytes.Buffer
+	if err := s.Serve(in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if got := s.Trace(); got != TraceVerbose {
+		t.Errorf("expected TraceVerbose after $/setTrace, got %q", got)
+	}
+}
+
+func TestInitializeRecordsTrace(t *testing.T) {
+	s := New()
+	RegisterLifecycle(s)
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"trace":"messages"}}`))
+	var out bytes.Buffer
+	if err := s.Serve(in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if got := s.Trace(); got != TraceMessages {
+		t.Errorf("expected TraceMessages from initialize, got %q", got)
+	}
+}
+
+func TestLogTraceIsSilentWhenTraceIsOff(t *testing.T) {
+	s := New()
+	s.Handle("trigger", func(params json.RawMessage) (interface{}, error) {
+		return nil, s.LogTrace("did a thing", "")
+	})
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","id":1,"method":"trigger"}`))
+	var out bytes.Buffer
+	sw := safeWriter{w: &out, mu: &sync.Mutex{}}
+	if err := s.Serve(in, sw); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	sw.mu.Lock()
+	got := out.String()
+	sw.mu.Unlock()
+	if strings.Contains(got, "$/logTrace") {
+		t.Errorf("expected no $/logTrace notification while trace is off, got %q", got)
+	}
+}
+
+func TestLogTraceSendsVerboseDetailAtTraceVerbose(t *testing.T) {
+	s := New()
+	s.setTrace(TraceVerbose)
+	s.Handle("trigger", func(params json.RawMessage) (interface{}, error) {
+		return nil, s.LogTrace("did a thing", "took 3ms")
+	})
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","id":1,"method":"trigger"}`))
+	var out bytes.Buffer
+	sw := safeWriter{w: &out, mu: &sync.Mutex{}}
+	if err := s.Serve(in, sw); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	sw.mu.Lock()
+	got := out.String()
+	sw.mu.Unlock()
+	if !strings.Contains(got, `"verbose":"took 3ms"`) {
+		t.Errorf("expected the verbose detail at TraceVerbose, got %q", got)
+	}
+}
+
+func TestLogTraceOmitsVerboseDetailAtTraceMessages(t *testing.T) {
+	s := New()
+	s.setTrace(TraceMessages)
+	s.Handle("trigger", func(params json.RawMessage) (interface{}, error) {
+		return nil, s.LogTrace("did a thing", "took 3ms")
+	})
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","id":1,"method":"trigger"}`))
+	var out bytes.Buffer
+	sw := safeWriter{w: &out, mu: &sync.Mutex{}}
+	if err := s.Serve(in, sw); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	sw.mu.Lock()
+	got := out.String()
+	sw.mu.Unlock()
+	if strings.Contains(got, "verbose") {
+		t.Errorf("expected no verbose detail at TraceMessages, got %q", got)
+	}
+	if !strings.Contains(got, "$/logTrace") {
+		t.Errorf("expected a $/logTrace notification at TraceMessages, got %q", got)
+	}
+}
+
+func TestLogMessageSendsRegardlessOfTraceLevel(t *testing.T) {
+	s := New()
+	s.Handle("trigger", func(params json.RawMessage) (interface{}, error) {
+		return nil, s.LogMessage(MessageTypeWarning, "analysis failed")
+	})
+
+	in := strings.NewReader(frame(`{"jsonrpc":"2.0","id":1,"method":"trigger"}`))
+	var out bytes.Buffer
+	sw := safeWriter{w: &out, mu: &sync.Mutex{}}
+	if err := s.Serve(in, sw); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	sw.mu.Lock()
+	got := out.String()
+	sw.mu.Unlock()
+	if !strings.Contains(got, `"method":"window/logMessage"`) || !strings.Contains(got, `"type":2`) {
+		t.Errorf("expected a window/logMessage notification with type 2, got %q", got)
+	}
+}
@@ -0,0 +1,24 @@
+package lsp
+
+import "encoding/json"
+
+// RegisterTypeDefinition wires textDocument/typeDefinition and
+// textDocument/implementation. Both need the same symbol resolution
+// textDocument/definition would (see RegisterDefinition), so both report
+// no location for now.
+func RegisterTypeDefinition(s *Server) {
+	s.Handle("textDocument/typeDefinition", func(params json.RawMessage) (interface{}, error) {
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	s.Handle("textDocument/implementation", func(params json.RawMessage) (interface{}, error) {
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
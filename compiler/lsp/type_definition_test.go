@@ -0,0 +1,43 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTypeDefinitionAndImplementationReturnNoLocationWithoutSymbolResolution(t *testing.T) {
+	s := New()
+	RegisterTypeDefinition(s)
+
+	params, _ := json.Marshal(TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"}})
+	for i, method := range []string{"textDocument/typeDefinition", "textDocument/implementation"} {
+		req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: method, Params: params})
+		var out bytes.Buffer
+		if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+			t.Fatalf("Serve(%s): %v", method, err)
+		}
+		if strings.Contains(out.String(), `"error"`) {
+			t.Errorf("case %d: expected a null result, not an error, got %q", i, out.String())
+		}
+	}
+}
+
+func TestInitializeAdvertisesTypeDefinitionAndImplementationProvidersOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterTypeDefinition(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"typeDefinitionProvider":true`) {
+		t.Errorf("expected typeDefinitionProvider:true, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), `"implementationProvider":true`) {
+		t.Errorf("expected implementationProvider:true, got %q", out.String())
+	}
+}
@@ -0,0 +1,33 @@
+package lsp
+
+import "encoding/json"
+
+// TypeHierarchyItem is one entry returned by textDocument/prepareTypeHierarchy
+// and walked further by typeHierarchy/supertypes and typeHierarchy/subtypes,
+// per the spec.
+type TypeHierarchyItem struct {
+	Name  string `json:"name"`
+	Kind  int    `json:"kind"`
+	URI   string `json:"uri"`
+	Range Range  `json:"range"`
+}
+
+// RegisterTypeHierarchy wires textDocument/prepareTypeHierarchy and the
+// typeHierarchy/supertypes and typeHierarchy/subtypes requests that walk
+// outward from it. Supertypes (a type's alias/base definition) and
+// subtypes (an interface's implementers) both need the kind of type
+// information that would live on a stype.UserType or stype.InterfaceType
+// in a symbol table — neither exists in this compiler yet (see
+// RegisterDocumentSymbol and RegisterDefinition for the same gap), so all
+// three requests report no result rather than fabricate a hierarchy.
+func RegisterTypeHierarchy(s *Server) {
+	s.Handle("textDocument/prepareTypeHierarchy", func(params json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+	s.Handle("typeHierarchy/supertypes", func(params json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+	s.Handle("typeHierarchy/subtypes", func(params json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+}
@@ -0,0 +1,39 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPrepareTypeHierarchyReturnsNoItemsWithoutASymbolTable(t *testing.T) {
+	s := New()
+	RegisterTypeHierarchy(s)
+
+	params, _ := json.Marshal(TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: "file:///a.fer"}})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/prepareTypeHierarchy", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if strings.Contains(out.String(), `"error"`) || strings.Contains(out.String(), `"result":"`) {
+		t.Errorf("expected a null result, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesTypeHierarchyProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterTypeHierarchy(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"typeHierarchyProvider":true`) {
+		t.Errorf("expected typeHierarchyProvider:true, got %q", out.String())
+	}
+}
@@ -0,0 +1,55 @@
+package lsp
+
+import "encoding/json"
+
+// FileChangeType mirrors the spec's FileChangeType enum used in FileEvent.
+const (
+	FileChangeCreated = 1
+	FileChangeChanged = 2
+	FileChangeDeleted = 3
+)
+
+// FileEvent is one entry of a workspace/didChangeWatchedFiles notification.
+type FileEvent struct {
+	URI  string `json:"uri"`
+	Type int    `json:"type"`
+}
+
+type didChangeWatchedFilesParams struct {
+	Changes []FileEvent `json:"changes"`
+}
+
+// RegisterWatchedFiles wires workspace/didChangeWatchedFiles.
+//
+// A real implementation would use this to invalidate whatever cached
+// analysis a fer.ret, ferret.lock or .fer change outside the editor made
+// stale, then re-publish diagnostics for the affected documents. Neither
+// exists yet: there's no compiled-module cache in an LSP session (nothing
+// in this package parses a fer.ret manifest or a module graph at all) and
+// no textDocument/publishDiagnostics has ever been sent by this server, so
+// there's nothing to invalidate and nothing to re-publish. The handler
+// still parses and is kept so the plumbing — and the client-side watcher
+// registration a real client expects, via client/registerCapability,
+// which this server also can't send yet since Serve has no way to issue a
+// request of its own to the client — has a single place to grow into.
+func RegisterWatchedFiles(s *Server) {
+	s.Handle("workspace/didChangeWatchedFiles", func(params json.RawMessage) (interface{}, error) {
+		var p didChangeWatchedFilesParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+}
+
+// Treating a fer.ret manifest as a first-class document — validating its
+// TOML syntax and keys, catching a malformed version constraint, offering
+// completion for [build]/[dependencies]/[remote] and dependency names out
+// of ferret.lock — needs a schema for what fer.ret is allowed to contain
+// and a parser for it, and neither exists anywhere in this repo: there's
+// no TOML library in go.mod, no type describing a manifest's sections, and
+// no lockfile format. RegisterDocumentSync treats every open document as
+// opaque text regardless of extension, so a fer.ret buffer today gets the
+// same (empty) completion and hover results as a .fer one; this note sits
+// next to the closest existing acknowledgment of fer.ret's absence until
+// a manifest format exists for an LSP handler to validate against.
@@ -0,0 +1,43 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDidChangeWatchedFilesAcceptsTheChangeList(t *testing.T) {
+	s := New()
+	RegisterWatchedFiles(s)
+
+	params, _ := json.Marshal(didChangeWatchedFilesParams{Changes: []FileEvent{
+		{URI: "file:///repo/fer.ret", Type: FileChangeChanged},
+		{URI: "file:///repo/ferret.lock", Type: FileChangeCreated},
+		{URI: "file:///repo/a.fer", Type: FileChangeDeleted},
+	}})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", Method: "workspace/didChangeWatchedFiles", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no response to a notification, got %q", out.String())
+	}
+}
+
+func TestDidChangeWatchedFilesRejectsMalformedParams(t *testing.T) {
+	s := New()
+	RegisterWatchedFiles(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "workspace/didChangeWatchedFiles", Params: json.RawMessage(`{"changes":"not-a-list"}`)})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if !strings.Contains(out.String(), `"error"`) {
+		t.Errorf("expected malformed params to surface as an error, got %q", out.String())
+	}
+}
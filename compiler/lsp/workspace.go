@@ -0,0 +1,59 @@
+package lsp
+
+import "strings"
+
+// WorkspaceFolder is one root folder of a (possibly multi-root) workspace,
+// per the spec's WorkspaceFolder shape.
+type WorkspaceFolder struct {
+	URI  string `json:"uri"`
+	Name string `json:"name"`
+}
+
+// setWorkspaceFolders records the roots a client passed to initialize, so
+// later requests can route a document URI to the root it belongs to. It's
+// a method on Server, not a separate Register*, because initialize already
+// owns the only place these are ever set (workspace/didChangeWorkspaceFolders
+// isn't handled; this server never changes a root after startup).
+func (s *Server) setWorkspaceFolders(folders []WorkspaceFolder) {
+	s.workspaceMu.Lock()
+	defer s.workspaceMu.Unlock()
+	s.workspaceFolders = folders
+}
+
+// WorkspaceFolders returns the roots the client advertised in initialize,
+// in the order it sent them.
+func (s *Server) WorkspaceFolders() []WorkspaceFolder {
+	s.workspaceMu.Lock()
+	defer s.workspaceMu.Unlock()
+	return append([]WorkspaceFolder(nil), s.workspaceFolders...)
+}
+
+// FolderForURI returns the workspace folder that contains uri: the
+// registered folder whose URI is the longest prefix of uri, so a nested
+// root wins over an outer one. It reports false if uri isn't inside any
+// known folder.
+//
+// This is routing only — there is no per-root compiler context to route
+// *into* yet. This package has no entry point that compiles a project at
+// all (see RegisterCodeAction and friends, which all precede any
+// typecheck phase), so "maintain separate compiler contexts per project
+// root" has nothing to maintain today; FolderForURI exists so that piece
+// can be wired in later without another pass over the multi-root
+// plumbing.
+func (s *Server) FolderForURI(uri string) (WorkspaceFolder, bool) {
+	s.workspaceMu.Lock()
+	defer s.workspaceMu.Unlock()
+
+	var best WorkspaceFolder
+	found := false
+	for _, f := range s.workspaceFolders {
+		if !strings.HasPrefix(uri, f.URI) {
+			continue
+		}
+		if !found || len(f.URI) > len(best.URI) {
+			best = f
+			found = true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,28 @@
+package lsp
+
+import "encoding/json"
+
+// WorkspaceSymbol is one entry in a workspace/symbol response, per the
+// spec.
+type WorkspaceSymbol struct {
+	Name     string   `json:"name"`
+	Kind     int      `json:"kind"`
+	Location Location `json:"location"`
+}
+
+// RegisterWorkspaceSymbol wires workspace/symbol. Querying "all loaded
+// modules in the compiled project" needs a project with more than one
+// module and a symbol table for each, neither of which exist — build only
+// ever sees a single input file (see the import-graph TODO in
+// compiler/cmd/build.go) — so it always answers with no matches.
+func RegisterWorkspaceSymbol(s *Server) {
+	s.Handle("workspace/symbol", func(params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Query string `json:"query"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return []WorkspaceSymbol{}, nil
+	})
+}
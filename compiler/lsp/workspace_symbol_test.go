@@ -0,0 +1,41 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWorkspaceSymbolReturnsNoMatchesWithNoProjectSymbolTable(t *testing.T) {
+	s := New()
+	RegisterWorkspaceSymbol(s)
+
+	params, _ := json.Marshal(map[string]string{"query": "main"})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "workspace/symbol", Params: params})
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"result":[]`) {
+		t.Errorf("expected no matches, got %q", out.String())
+	}
+}
+
+func TestInitializeAdvertisesWorkspaceSymbolProviderOnceRegistered(t *testing.T) {
+	s := New()
+	RegisterWorkspaceSymbol(s)
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	if !strings.Contains(out.String(), `"workspaceSymbolProvider":true`) {
+		t.Errorf("expected workspaceSymbolProvider:true once RegisterWorkspaceSymbol ran, got %q", out.String())
+	}
+}
@@ -0,0 +1,86 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInitializeRecordsWorkspaceFolders(t *testing.T) {
+	s := New()
+	RegisterLifecycle(s)
+
+	params, _ := json.Marshal(map[string]interface{}{
+		"workspaceFolders": []WorkspaceFolder{
+			{URI: "file:///repo/a", Name: "a"},
+			{URI: "file:///repo/b", Name: "b"},
+		},
+	})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize", Params: params})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	got := s.WorkspaceFolders()
+	if len(got) != 2 || got[0].URI != "file:///repo/a" || got[1].URI != "file:///repo/b" {
+		t.Errorf("expected both workspace folders recorded, got %+v", got)
+	}
+}
+
+func TestInitializeFallsBackToRootURI(t *testing.T) {
+	s := New()
+	RegisterLifecycle(s)
+
+	params, _ := json.Marshal(map[string]interface{}{"rootUri": "file:///repo"})
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize", Params: params})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	got := s.WorkspaceFolders()
+	if len(got) != 1 || got[0].URI != "file:///repo" {
+		t.Errorf("expected rootUri treated as a single workspace folder, got %+v", got)
+	}
+}
+
+func TestInitializeWithNoParamsLeavesWorkspaceFoldersEmpty(t *testing.T) {
+	s := New()
+	RegisterLifecycle(s)
+
+	req, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "initialize"})
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(frame(string(req))), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if strings.Contains(out.String(), `"error"`) {
+		t.Errorf("expected initialize with no params to still succeed, got %q", out.String())
+	}
+	if got := s.WorkspaceFolders(); len(got) != 0 {
+		t.Errorf("expected no workspace folders, got %+v", got)
+	}
+}
+
+func TestFolderForURIPicksTheLongestMatchingRoot(t *testing.T) {
+	s := New()
+	s.setWorkspaceFolders([]WorkspaceFolder{
+		{URI: "file:///repo", Name: "repo"},
+		{URI: "file:///repo/nested", Name: "nested"},
+	})
+
+	f, ok := s.FolderForURI("file:///repo/nested/a.fer")
+	if !ok || f.Name != "nested" {
+		t.Errorf("expected the nested root to win, got %+v, ok=%v", f, ok)
+	}
+
+	f, ok = s.FolderForURI("file:///repo/a.fer")
+	if !ok || f.Name != "repo" {
+		t.Errorf("expected the outer root for a file outside nested, got %+v, ok=%v", f, ok)
+	}
+
+	if _, ok := s.FolderForURI("file:///elsewhere/a.fer"); ok {
+		t.Errorf("expected no folder for a URI outside every root")
+	}
+}
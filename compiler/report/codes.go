@@ -0,0 +1,64 @@
+package report
+
+// Code is a stable identifier for one particular kind of diagnostic, e.g.
+// "FER0001", so tooling (and a human searching the web) can refer to it
+// independently of however its message happens to be phrased that day.
+// See Explain.
+type Code string
+
+const (
+	// CodeBuildFailed is assigned to any diagnostic produced by turning
+	// build's top-level error into a Report (see FromError) — today the
+	// only source of a Report, so it's also the only code defined. Once
+	// individual pipeline stages start reporting their own diagnostics,
+	// each distinct kind of failure should get its own code instead of
+	// sharing this one.
+	CodeBuildFailed Code = "FER0001"
+
+	// CodeUnreachableCode is assigned by `ferret vet`'s unreachable-code
+	// rule to a block no path from a function's entry can reach.
+	CodeUnreachableCode Code = "FER0002"
+)
+
+// Explanation is the extended description `ferret explain <code>` prints
+// for a Code, beyond the one-line Message a Report carries at the time
+// it's reported.
+type Explanation struct {
+	Code    Code
+	Summary string
+	Details string
+	Example string
+}
+
+// explanations is the registry Explain looks codes up in.
+var explanations = map[Code]Explanation{
+	CodeBuildFailed: {
+		Code:    CodeBuildFailed,
+		Summary: "The build pipeline failed before it could produce an executable.",
+		Details: "This is a catch-all code for any error build's top-level " +
+			"stages (reading the source, generating code, assembling or " +
+			"linking) return. The report's message names which stage " +
+			"failed and why; there's no finer-grained code to narrow it " +
+			"down further yet.",
+		Example: "ferret build --diagnostics-format json prog.fer",
+	},
+	CodeUnreachableCode: {
+		Code:    CodeUnreachableCode,
+		Summary: "Some code can never run.",
+		Details: "The named block has no path to it from the function's " +
+			"entry point, e.g. it follows an unconditional return. It's a " +
+			"warning rather than a build error: the compiler drops the " +
+			"block at -O1 and above either way, but leaving dead code in " +
+			"place usually means a logic mistake rather than an " +
+			"intentional no-op.",
+		Example: "ferret vet prog.fer",
+	},
+}
+
+// Explain looks up the extended description for a diagnostic code, for
+// `ferret explain <code>`. It reports false for a code nothing in this
+// build of the compiler has ever assigned.
+func Explain(code Code) (Explanation, bool) {
+	e, ok := explanations[code]
+	return e, ok
+}
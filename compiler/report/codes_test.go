@@ -0,0 +1,19 @@
+package report
+
+import "testing"
+
+func TestExplainFindsARegisteredCode(t *testing.T) {
+	e, ok := Explain(CodeBuildFailed)
+	if !ok {
+		t.Fatal("expected CodeBuildFailed to be explainable")
+	}
+	if e.Code != CodeBuildFailed || e.Summary == "" || e.Details == "" {
+		t.Errorf("unexpected explanation: %+v", e)
+	}
+}
+
+func TestExplainReportsFalseForAnUnknownCode(t *testing.T) {
+	if _, ok := Explain(Code("FER9999")); ok {
+		t.Error("expected an unregistered code to not be explainable")
+	}
+}
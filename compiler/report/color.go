@@ -0,0 +1,51 @@
+package report
+
+import (
+	"io"
+	"os"
+)
+
+// ColorMode selects when DisplayAll and DisplayLimited color their output,
+// for --color.
+type ColorMode string
+
+const (
+	ColorAuto   ColorMode = "auto"
+	ColorAlways ColorMode = "always"
+	ColorNever  ColorMode = "never"
+)
+
+// ResolveColor turns mode into the plain on/off color DisplayAll and
+// DisplayLimited take. ColorAlways and ColorNever are unconditional;
+// ColorAuto honors the NO_COLOR convention (https://no-color.org) and
+// otherwise colors only when w is a terminal, so redirecting build's
+// output to a file or CI log doesn't garble it with escape codes.
+func ResolveColor(mode ColorMode, w io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		if os.Getenv("NO_COLOR") != "" {
+			return false
+		}
+		return isTerminal(w)
+	}
+}
+
+// isTerminal reports whether w is a character device, the same check
+// os.Stdout/os.Stderr need before `ferret build | less` or `ferret build >
+// log.txt` turns off color. Anything other than *os.File (a bytes.Buffer in
+// a test, a pipe wired up some other way) is never a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,29 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestResolveColorAlwaysAndNeverIgnoreTheEnvironmentAndWriter(t *testing.T) {
+	if !ResolveColor(ColorAlways, &bytes.Buffer{}) {
+		t.Error("expected ColorAlways to color even a non-terminal writer")
+	}
+	if ResolveColor(ColorNever, os.Stdout) {
+		t.Error("expected ColorNever to disable color even for a terminal writer")
+	}
+}
+
+func TestResolveColorAutoHonorsNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if ResolveColor(ColorAuto, os.Stdout) {
+		t.Error("expected NO_COLOR to disable color even when -color=auto and the writer is a terminal")
+	}
+}
+
+func TestResolveColorAutoIsOffForANonTerminalWriter(t *testing.T) {
+	if ResolveColor(ColorAuto, &bytes.Buffer{}) {
+		t.Error("expected ColorAuto to disable color for a non-terminal writer like a bytes.Buffer")
+	}
+}
@@ -0,0 +1,40 @@
+package report
+
+import "strings"
+
+// Dedup drops a report if an earlier one in rs already covers the same
+// underlying problem: same File, Range, and normalized Code/Message. The
+// same problem often produces one Report per phase that happens to notice
+// it (e.g. an unresolved symbol flagged by both the analyzer and codegen),
+// and a user or an LSP client only needs to see it once. Order and the
+// first occurrence of each problem are preserved.
+func (rs Reports) Dedup() Reports {
+	type key struct {
+		file string
+		rng  Range
+		sig  string
+	}
+	seen := make(map[key]bool, len(rs))
+	out := make(Reports, 0, len(rs))
+	for _, r := range rs {
+		k := key{file: r.File, rng: r.Range, sig: dedupSignature(r)}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, r)
+	}
+	return out
+}
+
+// dedupSignature normalizes what makes two reports "the same problem" for
+// Dedup. A Code is already stable across however a phase happens to phrase
+// its message, so it's used whenever set; otherwise the Message is
+// lowercased and its whitespace collapsed, so two phases reporting the
+// same sentence with different capitalization or spacing still match.
+func dedupSignature(r Report) string {
+	if r.Code != "" {
+		return string(r.Code)
+	}
+	return strings.Join(strings.Fields(strings.ToLower(r.Message)), " ")
+}
@@ -0,0 +1,42 @@
+package report
+
+import "testing"
+
+func TestDedupDropsARepeatedProblemByCode(t *testing.T) {
+	rng := Range{Start: Position{Line: 3, Column: 1}, End: Position{Line: 3, Column: 5}}
+	rs := Reports{
+		{File: "main.fer", Range: rng, Code: CodeBuildFailed, Message: "unresolved symbol x", Phase: "analyze"},
+		{File: "main.fer", Range: rng, Code: CodeBuildFailed, Message: "x is not defined", Phase: "codegen"},
+	}
+
+	got := rs.Dedup()
+	if len(got) != 1 {
+		t.Fatalf("expected the second report to be dropped, got %+v", got)
+	}
+	if got[0].Phase != "analyze" {
+		t.Errorf("expected the first occurrence to be kept, got %+v", got[0])
+	}
+}
+
+func TestDedupDropsARepeatedProblemByNormalizedMessage(t *testing.T) {
+	rng := Range{Start: Position{Line: 1, Column: 1}, End: Position{Line: 1, Column: 1}}
+	rs := Reports{
+		{File: "main.fer", Range: rng, Message: "unused variable  y", Phase: "analyze"},
+		{File: "main.fer", Range: rng, Message: "Unused variable y", Phase: "lint"},
+	}
+
+	if got := rs.Dedup(); len(got) != 1 {
+		t.Errorf("expected a message that differs only in case/spacing to dedup, got %+v", got)
+	}
+}
+
+func TestDedupKeepsReportsAtDifferentLocations(t *testing.T) {
+	rs := Reports{
+		{File: "main.fer", Range: Range{Start: Position{Line: 1, Column: 1}}, Code: CodeBuildFailed, Phase: "analyze"},
+		{File: "main.fer", Range: Range{Start: Position{Line: 2, Column: 1}}, Code: CodeBuildFailed, Phase: "analyze"},
+	}
+
+	if got := rs.Dedup(); len(got) != 2 {
+		t.Errorf("expected reports at different locations to both survive, got %+v", got)
+	}
+}
@@ -0,0 +1,336 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Reports is an ordered collection of diagnostics ready to show a user, via
+// DisplayAll.
+type Reports []Report
+
+const (
+	displayColorError = "\x1b[31m"
+	displayColorWarn  = "\x1b[33m"
+	displayColorCaret = "\x1b[1m"
+	displayColorAdd   = "\x1b[32m"
+	displayColorReset = "\x1b[0m"
+)
+
+// DisplayAll writes every report to w in rustc/clang style: a file:line:col
+// header followed by the offending source line and a caret/underline
+// spanning Range. A Report with no File — today's only kind, see
+// FromError — has no source line to show, so it falls back to just the
+// header and message. color switches the severity/caret/diff ANSI codes on
+// or off; see ResolveColor.
+func (rs Reports) DisplayAll(w io.Writer, color bool) error {
+	for _, r := range rs {
+		if err := r.display(w, color); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasErrors reports whether any Report in rs has SeverityError, after
+// whatever severity changes a caller has already applied (see
+// PromoteWarnings) — the single place build decides whether a run failed.
+func (rs Reports) HasErrors() bool {
+	return rs.countBySeverity(SeverityError) > 0
+}
+
+// PromoteWarnings returns a copy of rs with every SeverityWarning report
+// upgraded to SeverityError, for --werror: CI wants a run with only
+// warnings to fail the same way one with errors does, and doing the
+// promotion here means every formatter and HasErrors sees it without
+// needing their own --werror handling.
+func (rs Reports) PromoteWarnings() Reports {
+	out := make(Reports, len(rs))
+	for i, r := range rs {
+		if r.Severity == SeverityWarning {
+			r.Severity = SeverityError
+		}
+		out[i] = r
+	}
+	return out
+}
+
+// DisplayLimited is DisplayAll followed by a one-line summary footer
+// ("N errors, M warnings" with a per-phase breakdown), but stops rendering
+// individual reports once maxErrors errors have been shown, replacing the
+// rest with a single "... and N more errors" line so a huge failure
+// cascade doesn't flood the terminal. maxErrors <= 0 means no limit.
+// Nothing is written for an empty rs, matching DisplayAll. color is passed
+// through to DisplayAll; see ResolveColor.
+func (rs Reports) DisplayLimited(w io.Writer, maxErrors int, color bool) error {
+	if len(rs) == 0 {
+		return nil
+	}
+
+	cutoff := len(rs)
+	if maxErrors > 0 {
+		errorsSeen := 0
+		for i, r := range rs {
+			if r.Severity != SeverityError {
+				continue
+			}
+			errorsSeen++
+			if errorsSeen > maxErrors {
+				cutoff = i
+				break
+			}
+		}
+	}
+
+	if err := rs[:cutoff].DisplayAll(w, color); err != nil {
+		return err
+	}
+	if remaining := rs[cutoff:].countBySeverity(SeverityError); remaining > 0 {
+		noun := "errors"
+		if remaining == 1 {
+			noun = "error"
+		}
+		if _, err := fmt.Fprintf(w, "... and %d more %s\n", remaining, noun); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, rs.summary())
+	return err
+}
+
+// countBySeverity returns how many reports in rs have severity s.
+func (rs Reports) countBySeverity(s Severity) int {
+	n := 0
+	for _, r := range rs {
+		if r.Severity == s {
+			n++
+		}
+	}
+	return n
+}
+
+// summary is DisplayLimited's footer: a total error/warning count, then a
+// breakdown per phase in the order phases first appear in rs. There's no
+// "in N files" suffix, the way a multi-file tool's summary line usually
+// ends — a build has exactly one input file today (see the import-graph
+// TODO in cmd/build.go), so that count would always read "in 1 file" and
+// say nothing a reader doesn't already know from the command line.
+func (rs Reports) summary() string {
+	type counts struct{ errors, warnings int }
+	byPhase := map[string]*counts{}
+	var order []string
+	var total counts
+
+	for _, r := range rs {
+		c, ok := byPhase[r.Phase]
+		if !ok {
+			c = &counts{}
+			byPhase[r.Phase] = c
+			order = append(order, r.Phase)
+		}
+		if r.Severity == SeverityWarning {
+			c.warnings++
+			total.warnings++
+		} else {
+			c.errors++
+			total.errors++
+		}
+	}
+
+	breakdown := make([]string, len(order))
+	for i, phase := range order {
+		c := byPhase[phase]
+		breakdown[i] = fmt.Sprintf("%s: %s", phase, countsText(c.errors, c.warnings))
+	}
+	return fmt.Sprintf("%s (%s)", countsText(total.errors, total.warnings), strings.Join(breakdown, "; "))
+}
+
+// countsText renders an error/warning count pair as e.g. "2 errors, 1
+// warning", omitting whichever side is zero.
+func countsText(errors, warnings int) string {
+	var parts []string
+	if errors > 0 {
+		parts = append(parts, pluralize(errors, "error"))
+	}
+	if warnings > 0 {
+		parts = append(parts, pluralize(warnings, "warning"))
+	}
+	if len(parts) == 0 {
+		return "0 errors"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pluralize renders n alongside noun, pluralized unless n is exactly 1.
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func (r Report) display(w io.Writer, color bool) error {
+	c, label := severityStyle(r.Severity, color)
+	codeSuffix := ""
+	if r.Code != "" {
+		codeSuffix = fmt.Sprintf(" [%s]", r.Code)
+	}
+	header := fmt.Sprintf("%s%s%s%s: %s", c, label, resetCode(color), codeSuffix, r.Message)
+
+	if r.File == "" {
+		_, err := fmt.Fprintln(w, header)
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "%s:%d:%d: %s\n", r.File, r.Range.Start.Line, r.Range.Start.Column, header); err != nil {
+		return err
+	}
+
+	line, err := readLine(r.File, r.Range.Start.Line)
+	if err != nil {
+		// The header is already written; a source line that can't be
+		// read (the file moved, the line is out of range) is just
+		// skipped rather than failing the whole report.
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, "    %s\n", line); err != nil {
+		return err
+	}
+	indent := strings.Repeat(" ", max(r.Range.Start.Column-1, 0))
+	if _, err := fmt.Fprintf(w, "    %s%s%s%s\n", indent, c+colorCode(color, displayColorCaret), caretUnderline(r.Range), resetCode(color)); err != nil {
+		return err
+	}
+	if err := r.displayFixes(w, line, color); err != nil {
+		return err
+	}
+	return r.displayRelated(w, color)
+}
+
+// displayRelated renders each secondary span attached to r beneath its
+// primary snippet: a "note:" line naming the span's file:line:col and
+// Message, then that span's own source line and underline, indented
+// further than the primary snippet so it reads as subordinate to it. A
+// span whose source line can't be read falls back to just the note, the
+// same way display does for a Report with no File.
+func (r Report) displayRelated(w io.Writer, color bool) error {
+	for _, rel := range r.Related {
+		if _, err := fmt.Fprintf(w, "    note: %s:%d:%d: %s\n", rel.File, rel.Range.Start.Line, rel.Range.Start.Column, rel.Message); err != nil {
+			return err
+		}
+		line, err := readLine(rel.File, rel.Range.Start.Line)
+		if err != nil {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "        %s\n", line); err != nil {
+			return err
+		}
+		indent := strings.Repeat(" ", max(rel.Range.Start.Column-1, 0))
+		if _, err := fmt.Fprintf(w, "        %s%s%s%s\n", indent, colorCode(color, displayColorCaret), caretUnderline(rel.Range), resetCode(color)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// displayFixes writes a "suggested fix" diff for each Fix attached to r: the
+// original line (read by display, above) prefixed with "-", and the line
+// after applying the fix's Edits prefixed with "+". A Fix whose Edits don't
+// all land on line (Range.Start.Line, already echoed by display) has
+// nothing to diff against and is skipped.
+func (r Report) displayFixes(w io.Writer, line string, color bool) error {
+	for _, fix := range r.Fixes {
+		applied, ok := applyEdits(line, r.Range.Start.Line, fix.Edits)
+		if !ok {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "    suggested fix: %s\n", fix.Description); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "    %s- %s%s\n", colorCode(color, displayColorError), line, resetCode(color)); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "    %s+ %s%s\n", colorCode(color, displayColorAdd), applied, resetCode(color)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyEdits returns line with edits applied, rightmost column first so
+// earlier edits' columns stay valid as later ones shift the text. It
+// reports false if any edit spans more than one line or falls outside
+// line's bounds, since neither can be represented against a single echoed
+// source line.
+func applyEdits(line string, lineNum int, edits []Edit) (string, bool) {
+	sorted := append([]Edit(nil), edits...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Range.Start.Column > sorted[j].Range.Start.Column
+	})
+	for _, e := range sorted {
+		if e.Range.Start.Line != lineNum || e.Range.End.Line != lineNum {
+			return "", false
+		}
+		start, end := e.Range.Start.Column-1, e.Range.End.Column-1
+		if start < 0 || end > len(line) || start > end {
+			return "", false
+		}
+		line = line[:start] + e.NewText + line[end:]
+	}
+	return line, true
+}
+
+// caretUnderline returns the "^^^^" run spanning rng, falling back to a
+// single caret when the range collapses to a point or crosses lines (a
+// single source line can't render an underline past its own end).
+func caretUnderline(rng Range) string {
+	width := rng.End.Column - rng.Start.Column
+	if rng.End.Line != rng.Start.Line || width <= 0 {
+		width = 1
+	}
+	return strings.Repeat("^", width)
+}
+
+func severityStyle(s Severity, color bool) (c, label string) {
+	if s == SeverityWarning {
+		return colorCode(color, displayColorWarn), "warning"
+	}
+	return colorCode(color, displayColorError), "error"
+}
+
+// colorCode returns code if color is enabled, else "" — every ANSI escape
+// display.go writes goes through this (or resetCode) so --color=never and
+// NO_COLOR produce plain text rather than garbled escape sequences.
+func colorCode(color bool, code string) string {
+	if color {
+		return code
+	}
+	return ""
+}
+
+// resetCode is colorCode for displayColorReset.
+func resetCode(color bool) string {
+	return colorCode(color, displayColorReset)
+}
+
+// readLine returns the 1-based nth line of path, without its trailing
+// newline.
+func readLine(path string, n int) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for i := 1; scanner.Scan(); i++ {
+		if i == n {
+			return scanner.Text(), nil
+		}
+	}
+	return "", fmt.Errorf("report: %s has no line %d", path, n)
+}
@@ -0,0 +1,246 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDisplayAllUnderlinesTheReportedRange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.fer")
+	if err := os.WriteFile(path, []byte("let x = y + 1\n"), 0o644); err != nil {
+		t.Fatalf("writing fixture source: %v", err)
+	}
+
+	reports := Reports{{
+		File:     path,
+		Range:    Range{Start: Position{Line: 1, Column: 9}, End: Position{Line: 1, Column: 10}},
+		Severity: SeverityError,
+		Code:     CodeBuildFailed,
+		Message:  "undefined identifier y",
+		Phase:    "analyze",
+	}}
+
+	var buf bytes.Buffer
+	if err := reports.DisplayAll(&buf, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+
+	if !strings.Contains(got, "main.fer:1:9:") {
+		t.Errorf("expected a file:line:col header, got:\n%s", got)
+	}
+	if !strings.Contains(got, "let x = y + 1") {
+		t.Errorf("expected the source line to be echoed, got:\n%s", got)
+	}
+	if !strings.Contains(got, strings.Repeat(" ", 8)+"\x1b[31m\x1b[1m^\x1b[0m") {
+		t.Errorf("expected a caret indented to column 9 and colored for an error, got:\n%s", got)
+	}
+	if !strings.Contains(got, "[FER0001]") {
+		t.Errorf("expected the code to be shown, got:\n%s", got)
+	}
+}
+
+func TestDisplayAllFallsBackWithNoFile(t *testing.T) {
+	reports := Reports{FromError("build", CodeBuildFailed, errBoom{})}
+
+	var buf bytes.Buffer
+	if err := reports.DisplayAll(&buf, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, "boom") || strings.Contains(got, ".fer") {
+		t.Errorf("expected just the header with no source snippet, got:\n%s", got)
+	}
+}
+
+func TestDisplayAllShowsASuggestedFixDiff(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.fer")
+	if err := os.WriteFile(path, []byte("let x = y + 1\n"), 0o644); err != nil {
+		t.Fatalf("writing fixture source: %v", err)
+	}
+
+	reports := Reports{{
+		File:     path,
+		Range:    Range{Start: Position{Line: 1, Column: 9}, End: Position{Line: 1, Column: 10}},
+		Severity: SeverityError,
+		Code:     CodeBuildFailed,
+		Message:  "undefined identifier y",
+		Phase:    "analyze",
+		Fixes: []Fix{{
+			Description: "rename to x",
+			Edits:       []Edit{{Range: Range{Start: Position{Line: 1, Column: 9}, End: Position{Line: 1, Column: 10}}, NewText: "x"}},
+		}},
+	}}
+
+	var buf bytes.Buffer
+	if err := reports.DisplayAll(&buf, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+
+	if !strings.Contains(got, "suggested fix: rename to x") {
+		t.Errorf("expected the fix description, got:\n%s", got)
+	}
+	if !strings.Contains(got, "- let x = y + 1") {
+		t.Errorf("expected the original line prefixed with -, got:\n%s", got)
+	}
+	if !strings.Contains(got, "+ let x = x + 1") {
+		t.Errorf("expected the line after applying the edit prefixed with +, got:\n%s", got)
+	}
+}
+
+func TestDisplayAllSkipsAFixThatSpansMultipleLines(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.fer")
+	if err := os.WriteFile(path, []byte("let x = y + 1\n"), 0o644); err != nil {
+		t.Fatalf("writing fixture source: %v", err)
+	}
+
+	reports := Reports{{
+		File:     path,
+		Range:    Range{Start: Position{Line: 1, Column: 9}, End: Position{Line: 1, Column: 10}},
+		Severity: SeverityError,
+		Message:  "undefined identifier y",
+		Phase:    "analyze",
+		Fixes: []Fix{{
+			Description: "spans lines",
+			Edits:       []Edit{{Range: Range{Start: Position{Line: 1, Column: 9}, End: Position{Line: 2, Column: 1}}, NewText: "x"}},
+		}},
+	}}
+
+	var buf bytes.Buffer
+	if err := reports.DisplayAll(&buf, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "suggested fix") {
+		t.Errorf("expected a multi-line fix to be skipped, got:\n%s", buf.String())
+	}
+}
+
+func TestDisplayLimitedPrintsASummaryFooter(t *testing.T) {
+	reports := Reports{
+		{Severity: SeverityError, Phase: "analyze", Message: "first"},
+		{Severity: SeverityError, Phase: "analyze", Message: "second"},
+		{Severity: SeverityWarning, Phase: "build", Message: "third"},
+	}
+
+	var buf bytes.Buffer
+	if err := reports.DisplayLimited(&buf, 0, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, "2 errors, 1 warning (analyze: 2 errors; build: 1 warning)") {
+		t.Errorf("expected a summary footer, got:\n%s", got)
+	}
+}
+
+func TestDisplayLimitedStopsAfterMaxErrorsAndCountsTheRest(t *testing.T) {
+	reports := Reports{
+		{Severity: SeverityError, Phase: "analyze", Message: "first"},
+		{Severity: SeverityError, Phase: "analyze", Message: "second"},
+		{Severity: SeverityError, Phase: "analyze", Message: "third"},
+	}
+
+	var buf bytes.Buffer
+	if err := reports.DisplayLimited(&buf, 1, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, "first") || strings.Contains(got, "second") || strings.Contains(got, "third") {
+		t.Errorf("expected only the first error to be shown, got:\n%s", got)
+	}
+	if !strings.Contains(got, "... and 2 more errors") {
+		t.Errorf("expected a count of the omitted errors, got:\n%s", got)
+	}
+	if !strings.Contains(got, "3 errors (analyze: 3 errors)") {
+		t.Errorf("expected the summary to still count every error, got:\n%s", got)
+	}
+}
+
+func TestDisplayLimitedWritesNothingForNoReports(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Reports(nil).DisplayLimited(&buf, 20, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output for an empty Reports, got:\n%s", buf.String())
+	}
+}
+
+func TestDisplayAllWritesNoANSICodesWhenColorIsOff(t *testing.T) {
+	reports := Reports{{Severity: SeverityError, Message: "boom", Phase: "build"}}
+
+	var buf bytes.Buffer
+	if err := reports.DisplayAll(&buf, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("expected no ANSI escape codes with color disabled, got %q", buf.String())
+	}
+}
+
+func TestDisplayAllShowsARelatedSpanAsANote(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.fer")
+	source := "let x int = 1\nx = \"oops\"\n"
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatalf("writing fixture source: %v", err)
+	}
+
+	reports := Reports{{
+		File:     path,
+		Range:    Range{Start: Position{Line: 2, Column: 5}, End: Position{Line: 2, Column: 11}},
+		Severity: SeverityError,
+		Message:  "cannot assign string to int",
+		Phase:    "analyze",
+		Related: []RelatedSpan{{
+			File:    path,
+			Range:   Range{Start: Position{Line: 1, Column: 9}, End: Position{Line: 1, Column: 12}},
+			Message: "expected because of this declaration",
+		}},
+	}}
+
+	var buf bytes.Buffer
+	if err := reports.DisplayAll(&buf, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+
+	if !strings.Contains(got, "note: "+path+":1:9: expected because of this declaration") {
+		t.Errorf("expected a note header for the related span, got:\n%s", got)
+	}
+	if !strings.Contains(got, "let x int = 1") {
+		t.Errorf("expected the related span's source line to be echoed, got:\n%s", got)
+	}
+}
+
+func TestHasErrorsIgnoresWarnings(t *testing.T) {
+	if (Reports{{Severity: SeverityWarning}}).HasErrors() {
+		t.Error("expected a warning-only Reports to have no errors")
+	}
+	if !(Reports{{Severity: SeverityWarning}, {Severity: SeverityError}}).HasErrors() {
+		t.Error("expected a Reports containing an error to report HasErrors")
+	}
+}
+
+func TestPromoteWarningsUpgradesWarningsToErrorsWithoutMutatingTheInput(t *testing.T) {
+	original := Reports{{Severity: SeverityWarning, Message: "unused"}, {Severity: SeverityError, Message: "boom"}}
+
+	promoted := original.PromoteWarnings()
+
+	if !promoted.HasErrors() || promoted[0].Severity != SeverityError {
+		t.Errorf("expected every report to be an error after promotion, got %+v", promoted)
+	}
+	if original[0].Severity != SeverityWarning {
+		t.Errorf("expected the original Reports to be left untouched, got %+v", original)
+	}
+}
+
+type errBoom struct{}
+
+func (errBoom) Error() string { return "boom" }
@@ -0,0 +1,119 @@
+// Package report defines the structured diagnostic the rest of the
+// compiler will report errors and warnings through, and a JSON
+// serialization of it for tools that can't speak LSP.
+//
+// There's no lexer/parser/analyzer pipeline producing these yet (see the
+// TODO in compiler/cmd/build.go) — today the only source of a Report is
+// build's single top-level error, via FromError. Once a real frontend
+// lands and starts collecting multiple diagnostics per file with real
+// source positions, this is where that collection type belongs.
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Severity classifies how serious a Report is.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Position is a 1-based line/column location within a source file.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Range spans from Start to End within a single file.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Report is a single diagnostic: a severity and message tied to a location
+// and the compilation phase that produced it, plus a stable Code (see
+// Explain), any free-text hints for fixing it, and any Fixes a phase knows
+// how to apply automatically.
+type Report struct {
+	File     string        `json:"file"`
+	Range    Range         `json:"range"`
+	Severity Severity      `json:"severity"`
+	Code     Code          `json:"code,omitempty"`
+	Message  string        `json:"message"`
+	Phase    string        `json:"phase"`
+	Hints    []string      `json:"hints,omitempty"`
+	Fixes    []Fix         `json:"fixes,omitempty"`
+	Related  []RelatedSpan `json:"related,omitempty"`
+}
+
+// RelatedSpan is a secondary span attached to a Report, pointing at another
+// location relevant to the diagnostic but not where it was raised — e.g. a
+// type mismatch naming the declaration the expected type came from.
+// Terminal output renders it as a "note:" beneath the primary snippet (see
+// display.go); over SARIF it becomes a relatedLocation.
+type RelatedSpan struct {
+	File    string `json:"file"`
+	Range   Range  `json:"range"`
+	Message string `json:"message"`
+}
+
+// Edit replaces the text spanning Range with NewText. Range.Start and
+// Range.End must lie on the same line; a phase proposing a multi-line edit
+// has no way to express it yet.
+type Edit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
+
+// Fix is a concrete, machine-applicable repair for the Report it's attached
+// to: a human-readable Description and the Edits that carry it out.
+// Terminal output renders it as a suggested-fix diff (see display.go); once
+// an LSP server exists it's what a client-side quick fix would apply
+// instead.
+type Fix struct {
+	Description string `json:"description"`
+	Edits       []Edit `json:"edits"`
+}
+
+// FromError builds a Report out of a plain error returned by a stage that
+// doesn't produce structured diagnostics of its own yet. File and Range
+// are left zero-valued since such an error carries no source position.
+func FromError(phase string, code Code, err error) Report {
+	return Report{Severity: SeverityError, Code: code, Message: err.Error(), Phase: phase}
+}
+
+// FormatJSON serializes reports as a JSON array, the format
+// `ferret build --diagnostics-format json` writes instead of plain text.
+func FormatJSON(reports []Report) (string, error) {
+	if reports == nil {
+		reports = []Report{}
+	}
+	b, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("report: marshaling: %w", err)
+	}
+	return string(b), nil
+}
+
+// FormatNDJSON serializes reports as newline-delimited JSON: one compact
+// object per line, no enclosing array. It's the format `ferret build --json`
+// writes, for a CI system or tool that wants to stream and parse diagnostics
+// one at a time rather than decode a whole array up front.
+func FormatNDJSON(reports []Report) (string, error) {
+	var buf bytes.Buffer
+	for _, r := range reports {
+		b, err := json.Marshal(r)
+		if err != nil {
+			return "", fmt.Errorf("report: marshaling: %w", err)
+		}
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,75 @@
+package report
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFromErrorCarriesMessagePhaseAndCode(t *testing.T) {
+	r := FromError("build", CodeBuildFailed, errors.New("boom"))
+	if r.Phase != "build" || r.Message != "boom" || r.Severity != SeverityError || r.Code != CodeBuildFailed {
+		t.Errorf("unexpected report: %+v", r)
+	}
+}
+
+func TestFormatJSONSerializesAllFields(t *testing.T) {
+	reports := []Report{{
+		File:     "main.fer",
+		Range:    Range{Start: Position{Line: 1, Column: 1}, End: Position{Line: 1, Column: 5}},
+		Severity: SeverityError,
+		Code:     CodeBuildFailed,
+		Message:  "something broke",
+		Phase:    "build",
+		Hints:    []string{"try again"},
+	}}
+
+	got, err := FormatJSON(reports)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{`"file": "main.fer"`, `"severity": "error"`, `"code": "FER0001"`, `"phase": "build"`, `"try again"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestFormatJSONEmptyReportsIsAnEmptyArray(t *testing.T) {
+	got, err := FormatJSON(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "[]" {
+		t.Errorf("expected an empty array, got %q", got)
+	}
+}
+
+func TestFormatNDJSONWritesOneCompactObjectPerLine(t *testing.T) {
+	reports := []Report{
+		{File: "a.fer", Severity: SeverityError, Message: "one"},
+		{File: "b.fer", Severity: SeverityWarning, Message: "two"},
+	}
+
+	got, err := FormatNDJSON(reports)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(got, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), got)
+	}
+	if !strings.Contains(lines[0], `"file":"a.fer"`) || !strings.Contains(lines[1], `"file":"b.fer"`) {
+		t.Errorf("expected one report per line in order, got %q", got)
+	}
+}
+
+func TestFormatNDJSONEmptyReportsIsEmpty(t *testing.T) {
+	got, err := FormatNDJSON(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("expected no output for no reports, got %q", got)
+	}
+}
@@ -0,0 +1,212 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// sarifLog is the subset of the SARIF 2.1.0 object model FormatSARIF
+// fills in: one run, one tool driver, and a result per Report. See
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/ for the full schema.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+// sarifRule is one entry in the driver's rule catalogue. A Report's Phase
+// becomes its ruleId, so every distinct phase that produced a diagnostic
+// gets exactly one rule here.
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID           string                 `json:"ruleId"`
+	Level            string                 `json:"level"`
+	Message          sarifMessage           `json:"message"`
+	Locations        []sarifLocation        `json:"locations,omitempty"`
+	RelatedLocations []sarifRelatedLocation `json:"relatedLocations,omitempty"`
+	Fixes            []sarifFix             `json:"fixes,omitempty"`
+}
+
+// sarifRelatedLocation is a Report's RelatedSpan, carrying its own location
+// and message the way SARIF's relatedLocations are meant to.
+type sarifRelatedLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          sarifMessage          `json:"message"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+// sarifFix is a suggested fix. A Report's free-form Hints have no
+// replacement text, so each becomes a fix with only a Description; a
+// Report's structured Fixes carry real Edits, so each becomes a fix with
+// ArtifactChanges a consumer can apply without a human reading the
+// description first.
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges,omitempty"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifMessage `json:"insertedContent"`
+}
+
+// sarifLevel maps a Severity to the SARIF result levels GitHub code
+// scanning and other consumers understand.
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// sarifFixFromFix converts a Report's Fix into a SARIF fix with one
+// artifactChange per Edit, against the file the Report itself was raised
+// against.
+func sarifFixFromFix(file string, fix Fix) sarifFix {
+	sf := sarifFix{Description: sarifMessage{Text: fix.Description}}
+	if file == "" {
+		return sf
+	}
+	replacements := make([]sarifReplacement, 0, len(fix.Edits))
+	for _, e := range fix.Edits {
+		replacements = append(replacements, sarifReplacement{
+			DeletedRegion: sarifRegion{
+				StartLine:   e.Range.Start.Line,
+				StartColumn: e.Range.Start.Column,
+				EndLine:     e.Range.End.Line,
+				EndColumn:   e.Range.End.Column,
+			},
+			InsertedContent: sarifMessage{Text: e.NewText},
+		})
+	}
+	sf.ArtifactChanges = []sarifArtifactChange{{
+		ArtifactLocation: sarifArtifactLocation{URI: file},
+		Replacements:     replacements,
+	}}
+	return sf
+}
+
+// FormatSARIF serializes reports as a SARIF 2.1.0 log, the format
+// `ferret build --diagnostics-format sarif` writes instead of plain text.
+// Each Report's Phase becomes its SARIF rule ID; its Hints become
+// description-only fixes and its Fixes become fixes with real
+// artifactChanges, since only the latter carry replacement text; and its
+// Related spans become relatedLocations.
+func FormatSARIF(reports []Report) (string, error) {
+	rules := []sarifRule{}
+	seenPhase := map[string]bool{}
+	results := make([]sarifResult, 0, len(reports))
+
+	for _, r := range reports {
+		if !seenPhase[r.Phase] {
+			seenPhase[r.Phase] = true
+			rules = append(rules, sarifRule{ID: r.Phase})
+		}
+
+		message := r.Message
+		if r.Code != "" {
+			message = fmt.Sprintf("%s: %s", r.Code, r.Message)
+		}
+		result := sarifResult{
+			RuleID:  r.Phase,
+			Level:   sarifLevel(r.Severity),
+			Message: sarifMessage{Text: message},
+		}
+		if r.File != "" {
+			result.Locations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: r.File},
+					Region: sarifRegion{
+						StartLine:   r.Range.Start.Line,
+						StartColumn: r.Range.Start.Column,
+						EndLine:     r.Range.End.Line,
+						EndColumn:   r.Range.End.Column,
+					},
+				},
+			}}
+		}
+		for _, hint := range r.Hints {
+			result.Fixes = append(result.Fixes, sarifFix{Description: sarifMessage{Text: hint}})
+		}
+		for _, fix := range r.Fixes {
+			result.Fixes = append(result.Fixes, sarifFixFromFix(r.File, fix))
+		}
+		for _, rel := range r.Related {
+			result.RelatedLocations = append(result.RelatedLocations, sarifRelatedLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: rel.File},
+					Region: sarifRegion{
+						StartLine:   rel.Range.Start.Line,
+						StartColumn: rel.Range.Start.Column,
+						EndLine:     rel.Range.End.Line,
+						EndColumn:   rel.Range.End.Column,
+					},
+				},
+				Message: sarifMessage{Text: rel.Message},
+			})
+		}
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "ferret", Rules: rules}},
+			Results: results,
+		}},
+	}
+
+	b, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("report: marshaling SARIF: %w", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,127 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatSARIFMapsPhaseToRuleAndHintsToFixes(t *testing.T) {
+	reports := []Report{{
+		File:     "main.fer",
+		Range:    Range{Start: Position{Line: 2, Column: 3}, End: Position{Line: 2, Column: 8}},
+		Severity: SeverityWarning,
+		Message:  "unused variable",
+		Phase:    "analyze",
+		Hints:    []string{"remove the declaration"},
+	}}
+
+	got, err := FormatSARIF(reports)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`"version": "2.1.0"`,
+		`"ruleId": "analyze"`,
+		`"id": "analyze"`,
+		`"level": "warning"`,
+		`"uri": "main.fer"`,
+		`"remove the declaration"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestFormatSARIFDedupesRulesByPhase(t *testing.T) {
+	reports := []Report{
+		{Phase: "build", Message: "first"},
+		{Phase: "build", Message: "second"},
+	}
+
+	got, err := FormatSARIF(reports)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(got, `"id": "build"`) != 1 {
+		t.Errorf("expected the build rule to appear exactly once, got:\n%s", got)
+	}
+	if strings.Count(got, `"ruleId": "build"`) != 2 {
+		t.Errorf("expected both results to reference the build rule, got:\n%s", got)
+	}
+}
+
+func TestFormatSARIFPrefixesMessageWithCode(t *testing.T) {
+	got, err := FormatSARIF([]Report{{Phase: "build", Code: CodeBuildFailed, Message: "boom"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(got, `"text": "FER0001: boom"`) {
+		t.Errorf("expected the message to be prefixed with the code, got:\n%s", got)
+	}
+}
+
+func TestFormatSARIFEmitsArtifactChangesForFixes(t *testing.T) {
+	reports := []Report{{
+		File:    "main.fer",
+		Phase:   "analyze",
+		Message: "undefined identifier y",
+		Fixes: []Fix{{
+			Description: "rename to x",
+			Edits:       []Edit{{Range: Range{Start: Position{Line: 1, Column: 9}, End: Position{Line: 1, Column: 10}}, NewText: "x"}},
+		}},
+	}}
+
+	got, err := FormatSARIF(reports)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`"rename to x"`,
+		`"artifactChanges"`,
+		`"startColumn": 9`,
+		`"insertedContent"`,
+		`"text": "x"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestFormatSARIFEmitsRelatedLocationsForRelatedSpans(t *testing.T) {
+	reports := []Report{{
+		File:    "main.fer",
+		Phase:   "analyze",
+		Message: "cannot assign string to int",
+		Related: []RelatedSpan{{
+			File:    "main.fer",
+			Range:   Range{Start: Position{Line: 1, Column: 9}, End: Position{Line: 1, Column: 12}},
+			Message: "expected because of this declaration",
+		}},
+	}}
+
+	got, err := FormatSARIF(reports)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`"relatedLocations"`,
+		`"expected because of this declaration"`,
+		`"startLine": 1`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestFormatSARIFOmitsLocationWithoutAFile(t *testing.T) {
+	got, err := FormatSARIF([]Report{{Phase: "build", Message: "boom"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(got, "physicalLocation") {
+		t.Errorf("expected no location for a report with no file, got:\n%s", got)
+	}
+}
@@ -0,0 +1,24 @@
+package report
+
+import "sort"
+
+// Sort returns a copy of rs ordered by File, then Range.Start.Line, then
+// Range.Start.Column, so diagnostics display in the same order every run
+// regardless of which order phases happened to append their reports in —
+// map iteration order among them shouldn't make output (and golden tests
+// built on it) flaky. A Report with no File (today's only kind, see
+// FromError) sorts before any with one.
+func (rs Reports) Sort() Reports {
+	out := append(Reports(nil), rs...)
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Range.Start.Line != b.Range.Start.Line {
+			return a.Range.Start.Line < b.Range.Start.Line
+		}
+		return a.Range.Start.Column < b.Range.Start.Column
+	})
+	return out
+}
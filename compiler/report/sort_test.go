@@ -0,0 +1,45 @@
+package report
+
+import "testing"
+
+func TestSortOrdersByFileThenLineThenColumn(t *testing.T) {
+	rs := Reports{
+		{File: "b.fer", Range: Range{Start: Position{Line: 1, Column: 1}}, Message: "b1"},
+		{File: "a.fer", Range: Range{Start: Position{Line: 2, Column: 1}}, Message: "a2"},
+		{File: "a.fer", Range: Range{Start: Position{Line: 1, Column: 5}}, Message: "a1-col5"},
+		{File: "a.fer", Range: Range{Start: Position{Line: 1, Column: 1}}, Message: "a1-col1"},
+	}
+
+	got := rs.Sort()
+
+	want := []string{"a1-col1", "a1-col5", "a2", "b1"}
+	for i, w := range want {
+		if got[i].Message != w {
+			t.Errorf("position %d: got %q, want %q (full order: %v)", i, got[i].Message, w, messagesOf(got))
+		}
+	}
+}
+
+func TestSortPutsReportsWithNoFileFirstAndDoesNotMutateTheInput(t *testing.T) {
+	rs := Reports{
+		{File: "a.fer", Message: "with file"},
+		{Message: "no file"},
+	}
+
+	got := rs.Sort()
+
+	if got[0].Message != "no file" {
+		t.Errorf("expected the report with no file to sort first, got %v", messagesOf(got))
+	}
+	if rs[0].Message != "with file" {
+		t.Errorf("expected Sort not to mutate its input, got %v", messagesOf(rs))
+	}
+}
+
+func messagesOf(rs Reports) []string {
+	out := make([]string, len(rs))
+	for i, r := range rs {
+		out[i] = r.Message
+	}
+	return out
+}
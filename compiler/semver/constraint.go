@@ -0,0 +1,141 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constraint is a set of comparators a Version must satisfy, all of them
+// (comma-separated in the source text) ANDed together — e.g. ">=1.2.0,
+// <2.0.0" requires both.
+type Constraint struct {
+	comparators []comparator
+}
+
+type comparator struct {
+	op  string
+	ver Version
+}
+
+// ParseConstraint parses a comma-separated list of comparator expressions.
+// Each expression is one of:
+//
+//	1.2.3     exact match
+//	^1.2.3    >=1.2.3, <2.0.0 (or <1.3.0 if major is 0, or <1.2.4 if major
+//	          and minor are both 0) — compatible within the leftmost
+//	          nonzero component, the way npm's ^ and cargo's default work
+//	~1.2.3    >=1.2.3, <1.3.0 — compatible within the minor version
+//	>=, <=, >, <, = VERSION   a plain comparison
+func ParseConstraint(s string) (Constraint, error) {
+	var c Constraint
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			return Constraint{}, fmt.Errorf("semver: empty constraint term in %q", s)
+		}
+		cmp, err := parseComparator(field)
+		if err != nil {
+			return Constraint{}, err
+		}
+		c.comparators = append(c.comparators, cmp...)
+	}
+	return c, nil
+}
+
+// parseComparator parses one comma-delimited field, expanding ^ and ~ into
+// the pair of plain comparators they mean.
+func parseComparator(field string) ([]comparator, error) {
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if rest, ok := cutPrefix(field, op); ok {
+			v, err := Parse(strings.TrimSpace(rest))
+			if err != nil {
+				return nil, err
+			}
+			return []comparator{{op: op, ver: v}}, nil
+		}
+	}
+	if rest, ok := cutPrefix(field, "^"); ok {
+		v, err := Parse(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, err
+		}
+		return []comparator{{op: ">=", ver: v}, {op: "<", ver: caretCeiling(v)}}, nil
+	}
+	if rest, ok := cutPrefix(field, "~"); ok {
+		v, err := Parse(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, err
+		}
+		return []comparator{{op: ">=", ver: v}, {op: "<", ver: tildeCeiling(v)}}, nil
+	}
+
+	v, err := Parse(field)
+	if err != nil {
+		return nil, err
+	}
+	return []comparator{{op: "=", ver: v}}, nil
+}
+
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return "", false
+	}
+	return s[len(prefix):], true
+}
+
+// caretCeiling returns the first version ^v excludes: the next version
+// that would change the leftmost nonzero of major/minor/patch.
+func caretCeiling(v Version) Version {
+	switch {
+	case v.Major > 0:
+		return Version{Major: v.Major + 1}
+	case v.Minor > 0:
+		return Version{Minor: v.Minor + 1}
+	default:
+		return Version{Patch: v.Patch + 1}
+	}
+}
+
+// tildeCeiling returns the first version ~v excludes: the next minor
+// version.
+func tildeCeiling(v Version) Version {
+	return Version{Major: v.Major, Minor: v.Minor + 1}
+}
+
+// Matches reports whether v satisfies every comparator in c.
+func (c Constraint) Matches(v Version) bool {
+	for _, cmp := range c.comparators {
+		if !cmp.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (cmp comparator) matches(v Version) bool {
+	switch cmp.op {
+	case "=":
+		return v.Compare(cmp.ver) == 0
+	case ">":
+		return v.Compare(cmp.ver) > 0
+	case ">=":
+		return v.Compare(cmp.ver) >= 0
+	case "<":
+		return v.Compare(cmp.ver) < 0
+	case "<=":
+		return v.Compare(cmp.ver) <= 0
+	default:
+		return false
+	}
+}
+
+// String renders c back to constraint text, space-joining its expanded
+// comparators — it never reconstructs ^ or ~, since by the time c exists
+// that shorthand has already been expanded away.
+func (c Constraint) String() string {
+	parts := make([]string, len(c.comparators))
+	for i, cmp := range c.comparators {
+		parts[i] = cmp.op + cmp.ver.String()
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,102 @@
+package semver
+
+import "testing"
+
+func mustVersion(t *testing.T, s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", s, err)
+	}
+	return v
+}
+
+func TestConstraintCaretAllowsCompatibleUpgrades(t *testing.T) {
+	c, err := ParseConstraint("^1.2.3")
+	if err != nil {
+		t.Fatalf("ParseConstraint: %v", err)
+	}
+	for _, ok := range []string{"1.2.3", "1.2.4", "1.9.0"} {
+		if !c.Matches(mustVersion(t, ok)) {
+			t.Errorf("^1.2.3 should match %s", ok)
+		}
+	}
+	for _, bad := range []string{"1.2.2", "2.0.0"} {
+		if c.Matches(mustVersion(t, bad)) {
+			t.Errorf("^1.2.3 should not match %s", bad)
+		}
+	}
+}
+
+func TestConstraintCaretBelowOneZerosNarrowsToPatchOrMinor(t *testing.T) {
+	minor, err := ParseConstraint("^0.2.3")
+	if err != nil {
+		t.Fatalf("ParseConstraint: %v", err)
+	}
+	if !minor.Matches(mustVersion(t, "0.2.9")) {
+		t.Error("^0.2.3 should match 0.2.9")
+	}
+	if minor.Matches(mustVersion(t, "0.3.0")) {
+		t.Error("^0.2.3 should not match 0.3.0")
+	}
+
+	patch, err := ParseConstraint("^0.0.3")
+	if err != nil {
+		t.Fatalf("ParseConstraint: %v", err)
+	}
+	if !patch.Matches(mustVersion(t, "0.0.3")) {
+		t.Error("^0.0.3 should match 0.0.3")
+	}
+	if patch.Matches(mustVersion(t, "0.0.4")) {
+		t.Error("^0.0.3 should not match 0.0.4")
+	}
+}
+
+func TestConstraintTildeAllowsOnlyPatchUpgrades(t *testing.T) {
+	c, err := ParseConstraint("~1.2.3")
+	if err != nil {
+		t.Fatalf("ParseConstraint: %v", err)
+	}
+	if !c.Matches(mustVersion(t, "1.2.9")) {
+		t.Error("~1.2.3 should match 1.2.9")
+	}
+	if c.Matches(mustVersion(t, "1.3.0")) {
+		t.Error("~1.2.3 should not match 1.3.0")
+	}
+}
+
+func TestConstraintExactRequiresEqualVersion(t *testing.T) {
+	c, err := ParseConstraint("1.2.3")
+	if err != nil {
+		t.Fatalf("ParseConstraint: %v", err)
+	}
+	if !c.Matches(mustVersion(t, "1.2.3")) {
+		t.Error("1.2.3 should match 1.2.3")
+	}
+	if c.Matches(mustVersion(t, "1.2.4")) {
+		t.Error("1.2.3 should not match 1.2.4")
+	}
+}
+
+func TestConstraintRangeANDsComparators(t *testing.T) {
+	c, err := ParseConstraint(">=1.2.0, <2.0.0")
+	if err != nil {
+		t.Fatalf("ParseConstraint: %v", err)
+	}
+	if !c.Matches(mustVersion(t, "1.5.0")) {
+		t.Error("expected 1.5.0 to satisfy >=1.2.0, <2.0.0")
+	}
+	if c.Matches(mustVersion(t, "2.0.0")) {
+		t.Error("expected 2.0.0 to fail >=1.2.0, <2.0.0")
+	}
+	if c.Matches(mustVersion(t, "1.1.0")) {
+		t.Error("expected 1.1.0 to fail >=1.2.0, <2.0.0")
+	}
+}
+
+func TestConstraintRejectsAMalformedTerm(t *testing.T) {
+	for _, s := range []string{"", ">=1.2", "^", "1.2.3,"} {
+		if _, err := ParseConstraint(s); err == nil {
+			t.Errorf("ParseConstraint(%q): expected an error", s)
+		}
+	}
+}
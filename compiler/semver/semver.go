@@ -0,0 +1,157 @@
+// Package semver parses and compares semantic versions (semver.org) and
+// the constraint expressions a dependency manager would resolve them
+// against: ^, ~, comparison operators, and comma-separated ranges.
+//
+// Nothing calls this package yet — there's no DependencyManager,
+// resolver, or fer.ret to read version requirements from (see the
+// package-manager TODOs elsewhere in this backlog), so it has no caller
+// until one of those exists. It's built now, the way compiler/version is
+// its own standalone package with no other package reaching into it, so
+// that future work can depend on a correct implementation instead of the
+// ad-hoc prefix matching a first draft of a resolver would be tempted to
+// write.
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version: major.minor.patch, with optional
+// dot-separated prerelease and build-metadata components.
+type Version struct {
+	Major, Minor, Patch int
+	Prerelease          string
+	Build               string
+}
+
+// Parse parses s as a semantic version, accepting a leading "v" the way
+// git tags and this package's own callers are likely to spell one (e.g.
+// "v1.2.3").
+func Parse(s string) (Version, error) {
+	orig := s
+	s = strings.TrimPrefix(s, "v")
+
+	var v Version
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		v.Build = s[i+1:]
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.Prerelease = s[i+1:]
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("semver: %q is not major.minor.patch", orig)
+	}
+	nums := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("semver: %q is not major.minor.patch: %q is not a non-negative integer", orig, part)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+
+	if v.Prerelease != "" {
+		for _, id := range strings.Split(v.Prerelease, ".") {
+			if id == "" {
+				return Version{}, fmt.Errorf("semver: %q has an empty prerelease identifier", orig)
+			}
+		}
+	}
+	return v, nil
+}
+
+// String renders v back to semver text, e.g. "1.2.3-rc.1+build.5".
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare orders v against other per semver precedence: major, then
+// minor, then patch, numerically; a version with a prerelease always
+// sorts before the same major.minor.patch with none; two prereleases
+// compare identifier by identifier (numeric identifiers compare
+// numerically, others lexically, numeric sorts before non-numeric at the
+// same position); build metadata is ignored entirely, per spec. Compare
+// returns -1, 0 or 1.
+func (v Version) Compare(other Version) int {
+	if c := compareInt(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// LessThan reports whether v sorts before other; shorthand for the
+// constraint operators below, which only ever need an ordering, not a
+// three-way result.
+func (v Version) LessThan(other Version) bool { return v.Compare(other) < 0 }
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease orders two dot-separated prerelease strings per
+// semver's precedence rule 11: a missing prerelease outranks any present
+// one, otherwise identifiers compare left to right.
+func comparePrerelease(a, b string) int {
+	if a == "" && b == "" {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifier(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(as), len(bs))
+}
+
+// compareIdentifier compares one dot-separated prerelease field: numeric
+// identifiers compare numerically and sort before any non-numeric
+// identifier, which otherwise compares as plain text (ASCII order).
+func compareIdentifier(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareInt(an, bn)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
@@ -0,0 +1,94 @@
+package semver
+
+import "testing"
+
+func TestParseAcceptsALeadingV(t *testing.T) {
+	v, err := Parse("v1.2.3")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if v.Major != 1 || v.Minor != 2 || v.Patch != 3 {
+		t.Errorf("got %+v, want 1.2.3", v)
+	}
+}
+
+func TestParseSplitsPrereleaseAndBuild(t *testing.T) {
+	v, err := Parse("1.2.3-rc.1+build.5")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if v.Prerelease != "rc.1" || v.Build != "build.5" {
+		t.Errorf("got prerelease %q build %q, want rc.1 / build.5", v.Prerelease, v.Build)
+	}
+}
+
+func TestParseRejectsMalformedVersions(t *testing.T) {
+	for _, s := range []string{"1.2", "1.2.3.4", "1.x.3", "-1.2.3", "1.2.-3"} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q): expected an error", s)
+		}
+	}
+}
+
+func TestStringRoundTrips(t *testing.T) {
+	for _, s := range []string{"1.2.3", "1.2.3-rc.1", "1.2.3+build.5", "1.2.3-rc.1+build.5"} {
+		v, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", s, err)
+		}
+		if got := v.String(); got != s {
+			t.Errorf("String() = %q, want %q", got, s)
+		}
+	}
+}
+
+func TestCompareOrdersMajorMinorPatch(t *testing.T) {
+	cases := []struct{ a, b string }{
+		{"1.0.0", "2.0.0"},
+		{"1.1.0", "1.2.0"},
+		{"1.1.1", "1.1.2"},
+	}
+	for _, c := range cases {
+		a, _ := Parse(c.a)
+		b, _ := Parse(c.b)
+		if !a.LessThan(b) {
+			t.Errorf("%s should be less than %s", c.a, c.b)
+		}
+		if b.LessThan(a) {
+			t.Errorf("%s should not be less than %s", c.b, c.a)
+		}
+	}
+}
+
+func TestComparePrereleaseOrdersBeforeRelease(t *testing.T) {
+	rc, _ := Parse("1.0.0-rc.1")
+	release, _ := Parse("1.0.0")
+	if !rc.LessThan(release) {
+		t.Error("1.0.0-rc.1 should sort before 1.0.0")
+	}
+}
+
+func TestComparePrereleaseOrdersNumericBeforeAlphanumeric(t *testing.T) {
+	cases := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0"}
+	for i := 0; i < len(cases)-1; i++ {
+		a, err := Parse(cases[i])
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", cases[i], err)
+		}
+		b, err := Parse(cases[i+1])
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", cases[i+1], err)
+		}
+		if !a.LessThan(b) {
+			t.Errorf("%s should sort before %s", cases[i], cases[i+1])
+		}
+	}
+}
+
+func TestCompareIgnoresBuildMetadata(t *testing.T) {
+	a, _ := Parse("1.0.0+build.1")
+	b, _ := Parse("1.0.0+build.2")
+	if a.Compare(b) != 0 {
+		t.Errorf("build metadata should not affect ordering: %s vs %s", a, b)
+	}
+}
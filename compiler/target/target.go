@@ -0,0 +1,105 @@
+// Package target describes the platforms the backend can generate code and
+// executables for, selected from a target triple such as
+// "x86_64-linux-gnu" or "x86_64-pc-windows-msvc".
+package target
+
+import "fmt"
+
+// OS identifies the target operating system.
+type OS int
+
+const (
+	Linux OS = iota
+	Windows
+	MacOS
+)
+
+// Target is a fully resolved compilation target.
+type Target struct {
+	OS OS
+}
+
+// Default is the target used when none is specified on the command line.
+var Default = Target{OS: Linux}
+
+// Parse resolves a target triple's OS component. Only the OS matters to the
+// backend today; vendor/abi fields are accepted but ignored. The arch
+// component is checked just enough to reject triples that name a real,
+// different architecture (e.g. "arm64-darwin") instead of silently
+// generating x86-64 code for them, since x86-64 is the only architecture
+// the generator supports.
+func Parse(triple string) (Target, error) {
+	if arch := unsupportedArch(triple); arch != "" {
+		return Target{}, fmt.Errorf("target: unsupported architecture %q in %q; only x86-64 is supported", arch, triple)
+	}
+	switch {
+	case contains(triple, "windows"):
+		return Target{OS: Windows}, nil
+	case contains(triple, "darwin"), contains(triple, "macos"), contains(triple, "apple"):
+		return Target{OS: MacOS}, nil
+	case contains(triple, "linux"), triple == "":
+		return Target{OS: Linux}, nil
+	default:
+		return Target{}, fmt.Errorf("target: unrecognized triple %q", triple)
+	}
+}
+
+// unsupportedArch returns the name of the architecture component of triple
+// if it names something other than x86-64, or "" if the triple doesn't
+// mention an architecture we recognize as unsupported.
+func unsupportedArch(triple string) string {
+	for _, arch := range []string{"arm64", "aarch64", "armv7", "arm", "riscv64", "i386", "i686", "x86_32"} {
+		if contains(triple, arch) {
+			return arch
+		}
+	}
+	return ""
+}
+
+func contains(s, substr string) bool {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i:i+len(substr)] == substr {
+			return true
+		}
+	}
+	return false
+}
+
+// EntrySymbol is the program entry point's linker-visible symbol name.
+func (t Target) EntrySymbol() string {
+	switch t.OS {
+	case Windows:
+		return "mainCRTStartup"
+	case MacOS:
+		return "_main"
+	default:
+		return "_start"
+	}
+}
+
+// LibCLinkArgs returns the extra linker arguments needed to pull in the
+// platform C library, for programs that declare `extern fn` bindings into
+// it. Callers only add these when such a declaration is present, so a
+// program with none stays fully static.
+func (t Target) LibCLinkArgs() []string {
+	switch t.OS {
+	case Windows:
+		return []string{"-defaultlib:msvcrt"}
+	case MacOS:
+		return []string{"-lSystem"}
+	default:
+		return []string{"-lc", "--dynamic-linker", "/lib64/ld-linux-x86-64.so.2"}
+	}
+}
+
+// ObjFormat is the nasm `-f` object format for this target.
+func (t Target) ObjFormat() string {
+	switch t.OS {
+	case Windows:
+		return "win64"
+	case MacOS:
+		return "macho64"
+	default:
+		return "elf64"
+	}
+}
@@ -0,0 +1,45 @@
+package target
+
+import "testing"
+
+func TestParseRecognizesEachOS(t *testing.T) {
+	cases := map[string]OS{
+		"":                       Linux,
+		"x86_64-linux-gnu":       Linux,
+		"x86_64-pc-windows-msvc": Windows,
+		"x86_64-apple-darwin":    MacOS,
+	}
+	for triple, want := range cases {
+		got, err := Parse(triple)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", triple, err)
+		}
+		if got.OS != want {
+			t.Errorf("Parse(%q).OS = %v, want %v", triple, got.OS, want)
+		}
+	}
+}
+
+func TestParseRejectsUnknownTriple(t *testing.T) {
+	if _, err := Parse("riscv64-unknown-freebsd"); err == nil {
+		t.Errorf("expected an error for an unsupported triple")
+	}
+}
+
+func TestParseRejectsNonX86Architectures(t *testing.T) {
+	triples := []string{"arm64-apple-darwin", "aarch64-linux-gnu", "i686-pc-windows-msvc"}
+	for _, triple := range triples {
+		if _, err := Parse(triple); err == nil {
+			t.Errorf("Parse(%q): expected an unsupported-architecture error", triple)
+		}
+	}
+}
+
+func TestEntrySymbolPerTarget(t *testing.T) {
+	if (Target{OS: Windows}).EntrySymbol() != "mainCRTStartup" {
+		t.Errorf("expected the Windows CRT entry symbol")
+	}
+	if (Target{OS: MacOS}).EntrySymbol() != "_main" {
+		t.Errorf("expected the underscore-prefixed macOS entry symbol")
+	}
+}
@@ -0,0 +1,100 @@
+// Package types defines Ferret's internal type representation used by the
+// analyzer and later compiler stages.
+package types
+
+import "fmt"
+
+// Kind identifies the shape of a Type without needing a type switch at every
+// call site.
+type Kind int
+
+const (
+	KindPrimitive Kind = iota
+	KindPointer
+	KindArray
+	KindStruct
+)
+
+// Type is implemented by every type the analyzer can reason about.
+type Type interface {
+	Kind() Kind
+	// String returns a human readable, stable representation of the type,
+	// used both for diagnostics and as a cache key component.
+	String() string
+}
+
+// Primitive is a built-in scalar type such as i32, f64 or bool.
+type Primitive struct {
+	Name string
+}
+
+func (p *Primitive) Kind() Kind     { return KindPrimitive }
+func (p *Primitive) String() string { return p.Name }
+
+// Pointer is a pointer to another type.
+type Pointer struct {
+	Elem Type
+}
+
+func (p *Pointer) Kind() Kind     { return KindPointer }
+func (p *Pointer) String() string { return fmt.Sprintf("*%s", p.Elem.String()) }
+
+// Array is a fixed-size array of elements of the same type.
+type Array struct {
+	Elem Type
+	Len  int
+}
+
+func (a *Array) Kind() Kind     { return KindArray }
+func (a *Array) String() string { return fmt.Sprintf("[%d]%s", a.Len, a.Elem.String()) }
+
+// Struct is a named aggregate of fields, compared by identity (name), not
+// structurally.
+type Struct struct {
+	Name   string
+	Fields map[string]Type
+}
+
+func (s *Struct) Kind() Kind     { return KindStruct }
+func (s *Struct) String() string { return s.Name }
+
+var (
+	I8   = &Primitive{Name: "i8"}
+	I16  = &Primitive{Name: "i16"}
+	I32  = &Primitive{Name: "i32"}
+	I64  = &Primitive{Name: "i64"}
+	F32  = &Primitive{Name: "f32"}
+	F64  = &Primitive{Name: "f64"}
+	Bool = &Primitive{Name: "bool"}
+	Str  = &Primitive{Name: "str"}
+)
+
+// numericRank orders numeric primitives from narrowest to widest so callers
+// can decide whether a widening conversion is implicit-safe.
+var numericRank = map[string]int{
+	"i8": 0, "i16": 1, "i32": 2, "i64": 3,
+	"f32": 4, "f64": 5,
+}
+
+// IsNumeric reports whether t is one of the built-in numeric primitives.
+func IsNumeric(t Type) bool {
+	p, ok := t.(*Primitive)
+	if !ok {
+		return false
+	}
+	_, ok = numericRank[p.Name]
+	return ok
+}
+
+// NumericRank returns the widening rank of a numeric primitive, or -1 if t
+// is not numeric.
+func NumericRank(t Type) int {
+	p, ok := t.(*Primitive)
+	if !ok {
+		return -1
+	}
+	if r, ok := numericRank[p.Name]; ok {
+		return r
+	}
+	return -1
+}
@@ -0,0 +1,37 @@
+// Package version holds the compiler's own identity: a semantic version,
+// the git commit it was built from, and the date it was built, all of
+// which are baked in at build time rather than read from anywhere at
+// runtime.
+package version
+
+// Version, Commit, and Date are overridden at build time via linker flags,
+// e.g.:
+//
+//	go build -ldflags "-X github.com/itsfuad/ferret-compiler/compiler/version.Version=1.2.0 \
+//	  -X github.com/itsfuad/ferret-compiler/compiler/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/itsfuad/ferret-compiler/compiler/version.Date=$(date -u +%Y-%m-%d)"
+//
+// A binary built without those flags (e.g. `go run`, `go test`) keeps
+// these defaults, which is why String always has something to print.
+var (
+	Version = "dev"
+	Commit  = "unknown"
+	Date    = "unknown"
+)
+
+// SupportedTargets lists the target triples `ferret build -target` and
+// `ferret run -target` accept today, kept here rather than derived from
+// compiler/target so this list reads the same whether or not a given
+// triple's vendor/abi suffix happens to matter to target.Parse.
+var SupportedTargets = []string{
+	"x86_64-linux-gnu",
+	"x86_64-pc-windows-msvc",
+	"x86_64-apple-darwin",
+}
+
+// String formats the version line `ferret version` prints: the version
+// followed by its commit and build date in parentheses, the way `go
+// version` and most self-hosted compilers report themselves.
+func String() string {
+	return Version + " (" + Commit + ", built " + Date + ")"
+}
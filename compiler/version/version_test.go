@@ -0,0 +1,24 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStringIncludesVersionCommitAndDate(t *testing.T) {
+	got := String()
+	for _, want := range []string{Version, Commit, Date} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %q, expected it to contain %q", got, want)
+		}
+	}
+}
+
+func TestSupportedTargetsIncludesEachHostOS(t *testing.T) {
+	joined := strings.Join(SupportedTargets, " ")
+	for _, want := range []string{"linux", "windows", "darwin"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("SupportedTargets = %v, expected an entry mentioning %q", SupportedTargets, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Package backend holds end-to-end tests over the codegen backend: golden
+// comparisons of its generated assembly, and (where nasm/ld are available)
+// actually running the result.
+//
+// There's no .fer frontend yet (see the TODO in compiler/cmd/build.go), so
+// fixtures are built directly as compiler/ast trees instead of parsed
+// source files. Once a parser lands, these should read small .fer files
+// from testdata instead.
+package backend
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+	"github.com/itsfuad/ferret-compiler/compiler/codegen/x86"
+)
+
+// update regenerates the golden files from the generator's current output
+// instead of comparing against them: `go test ./internal/backend -update`.
+var update = flag.Bool("update", false, "update golden files instead of comparing against them")
+
+type goldenCase struct {
+	name       string
+	moduleName string
+	fns        []*ast.FunctionDecl
+	externs    []*ast.ExternFuncDecl
+	opts       x86.GeneratorOptions
+}
+
+func goldenCases() []goldenCase {
+	return []goldenCase{
+		{
+			name:       "add",
+			moduleName: "add",
+			fns: []*ast.FunctionDecl{{
+				Name:   "main",
+				Params: nil,
+				Body: []ast.Stmt{
+					&ast.ReturnStmt{Value: &ast.BinaryExpr{Op: ast.OpAdd, Left: &ast.IntLiteral{Value: 2}, Right: &ast.IntLiteral{Value: 3}}},
+				},
+			}},
+		},
+		{
+			name:       "divzero-trap",
+			moduleName: "divzero",
+			fns: []*ast.FunctionDecl{{
+				Name:   "main",
+				Params: []ast.Param{{Name: "a", Type: "i32"}, {Name: "b", Type: "i32"}},
+				Body: []ast.Stmt{
+					&ast.ReturnStmt{Value: &ast.BinaryExpr{Op: ast.OpDiv, Left: &ast.Ident{Name: "a"}, Right: &ast.Ident{Name: "b"}}},
+				},
+			}},
+		},
+		{
+			name:       "extern-call",
+			moduleName: "externcall",
+			fns: []*ast.FunctionDecl{{
+				Name: "main",
+				Body: []ast.Stmt{
+					&ast.ExprStmt{X: &ast.FunctionCallExpr{Callee: "exit", Args: []ast.Expr{&ast.IntLiteral{Value: 0}}}},
+					&ast.ReturnStmt{},
+				},
+			}},
+			externs: []*ast.ExternFuncDecl{{Name: "exit", Params: []ast.Param{{Name: "code", Type: "i32"}}, ReturnType: "void"}},
+		},
+		{
+			name:       "entry-trampoline",
+			moduleName: "entrytrampoline",
+			fns: []*ast.FunctionDecl{{
+				Name: "main",
+				Body: []ast.Stmt{&ast.ReturnStmt{Value: &ast.IntLiteral{Value: 5}}},
+			}},
+			opts: x86.GeneratorOptions{EntryModule: true},
+		},
+	}
+}
+
+func TestGoldenAssembly(t *testing.T) {
+	for _, c := range goldenCases() {
+		t.Run(c.name, func(t *testing.T) {
+			gen := x86.NewGeneratorWithOptions(c.opts)
+			got := gen.Generate(c.moduleName, c.fns, c.externs)
+
+			goldenPath := filepath.Join("testdata", c.name+".asm.golden")
+			if *update {
+				if err := os.WriteFile(goldenPath, []byte(got), 0o644); err != nil {
+					t.Fatalf("writing golden file: %v", err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("reading golden file (run with -update to create it): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("generated assembly for %s does not match %s; rerun with -update if the change is intentional\ngot:\n%s\nwant:\n%s", c.name, goldenPath, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package backend
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/itsfuad/ferret-compiler/compiler/ast"
+	"github.com/itsfuad/ferret-compiler/compiler/backend/driver"
+	"github.com/itsfuad/ferret-compiler/compiler/codegen/x86"
+	"github.com/itsfuad/ferret-compiler/compiler/target"
+	"github.com/itsfuad/ferret-compiler/runtime/rt"
+)
+
+// TestRunPrintThenExit assembles, links and actually executes a generated
+// program, asserting on its stdout and exit code rather than just its
+// assembly text. It's skipped wherever nasm or the platform linker aren't
+// installed, which is most developer machines; CI is expected to have them.
+func TestRunPrintThenExit(t *testing.T) {
+	if _, err := exec.LookPath("nasm"); err != nil {
+		t.Skip("nasm not installed")
+	}
+	if _, err := exec.LookPath("ld"); err != nil {
+		t.Skip("ld not installed")
+	}
+
+	fn := &ast.FunctionDecl{
+		Name: "main",
+		Body: []ast.Stmt{
+			&ast.ExprStmt{X: &ast.FunctionCallExpr{Callee: "println", Args: []ast.Expr{&ast.IntLiteral{Value: 7}}}},
+			&ast.ExprStmt{X: &ast.FunctionCallExpr{Callee: "exit", Args: []ast.Expr{&ast.IntLiteral{Value: 0}}}},
+			&ast.ReturnStmt{},
+		},
+	}
+	exitDecl := &ast.ExternFuncDecl{Name: "exit", Params: []ast.Param{{Name: "code", Type: "i32"}}, ReturnType: "void"}
+
+	gen := x86.NewGenerator()
+	asm := gen.Generate("printexit", []*ast.FunctionDecl{fn}, []*ast.ExternFuncDecl{exitDecl})
+
+	dir := t.TempDir()
+	asmPath := dir + "/printexit.asm"
+	if err := os.WriteFile(asmPath, []byte(asm), 0o644); err != nil {
+		t.Fatalf("writing assembly: %v", err)
+	}
+
+	t1 := target.Default
+	objPath, err := driver.Assemble(asmPath, t1)
+	if err != nil {
+		t.Fatalf("assembling: %v", err)
+	}
+
+	rtPath := dir + "/rt.asm"
+	if err := os.WriteFile(rtPath, []byte(rt.Source), 0o644); err != nil {
+		t.Fatalf("writing runtime source: %v", err)
+	}
+	rtObjPath, err := driver.Assemble(rtPath, t1)
+	if err != nil {
+		t.Fatalf("assembling runtime: %v", err)
+	}
+
+	exePath := dir + "/printexit"
+	if err := driver.Link([]string{objPath, rtObjPath}, exePath, t1, driver.LinkOptions{LinkLibC: true}); err != nil {
+		t.Fatalf("linking: %v", err)
+	}
+
+	cmd := exec.Command(exePath)
+	out, err := cmd.Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			t.Fatalf("running %s: %v", exePath, err)
+		}
+	}
+	if got := strings.TrimSpace(string(out)); got != "7" {
+		t.Errorf("expected stdout %q, got %q", "7", got)
+	}
+	if cmd.ProcessState.ExitCode() != 0 {
+		t.Errorf("expected exit code 0, got %d", cmd.ProcessState.ExitCode())
+	}
+}
+
+// TestRunArithmeticChainAtO1ExercisesRegisterAllocatorSpills builds and
+// actually runs a -O1 program whose return expression is a 10-operand
+// right-associated add chain — more live temporaries than physRegs has
+// physical registers, so the register allocator's spill path has to run.
+// None of the other execution tests build above -O0, so this is the only
+// end-to-end check that a spill produces an instruction stream NASM will
+// actually assemble, rather than just text the unit tests compare against.
+func TestRunArithmeticChainAtO1ExercisesRegisterAllocatorSpills(t *testing.T) {
+	if _, err := exec.LookPath("nasm"); err != nil {
+		t.Skip("nasm not installed")
+	}
+	if _, err := exec.LookPath("ld"); err != nil {
+		t.Skip("ld not installed")
+	}
+
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	params := make([]ast.Param, len(names))
+	args := make([]ast.Expr, len(names))
+	sum := 0
+	for i, n := range names {
+		params[i] = ast.Param{Name: n, Type: "i32"}
+		args[i] = &ast.IntLiteral{Value: int64(i + 1)}
+		sum += i + 1
+	}
+
+	sumFn := &ast.FunctionDecl{
+		Name:   "sum10",
+		Params: params,
+		Body:   []ast.Stmt{&ast.ReturnStmt{Value: rightAssocAddChain(names)}},
+	}
+	mainFn := &ast.FunctionDecl{
+		Name: "main",
+		Body: []ast.Stmt{
+			&ast.ExprStmt{X: &ast.FunctionCallExpr{Callee: "println", Args: []ast.Expr{
+				&ast.FunctionCallExpr{Callee: "sum10", Args: args},
+			}}},
+			&ast.ExprStmt{X: &ast.FunctionCallExpr{Callee: "exit", Args: []ast.Expr{&ast.IntLiteral{Value: 0}}}},
+			&ast.ReturnStmt{},
+		},
+	}
+	exitDecl := &ast.ExternFuncDecl{Name: "exit", Params: []ast.Param{{Name: "code", Type: "i32"}}, ReturnType: "void"}
+
+	gen := x86.NewGeneratorWithOptions(x86.GeneratorOptions{OptLevel: 1})
+	asm := gen.Generate("sumchain", []*ast.FunctionDecl{sumFn, mainFn}, []*ast.ExternFuncDecl{exitDecl})
+
+	dir := t.TempDir()
+	asmPath := dir + "/sumchain.asm"
+	if err := os.WriteFile(asmPath, []byte(asm), 0o644); err != nil {
+		t.Fatalf("writing assembly: %v", err)
+	}
+
+	t1 := target.Default
+	objPath, err := driver.Assemble(asmPath, t1)
+	if err != nil {
+		t.Fatalf("assembling: %v", err)
+	}
+
+	rtPath := dir + "/rt.asm"
+	if err := os.WriteFile(rtPath, []byte(rt.Source), 0o644); err != nil {
+		t.Fatalf("writing runtime source: %v", err)
+	}
+	rtObjPath, err := driver.Assemble(rtPath, t1)
+	if err != nil {
+		t.Fatalf("assembling runtime: %v", err)
+	}
+
+	exePath := dir + "/sumchain"
+	if err := driver.Link([]string{objPath, rtObjPath}, exePath, t1, driver.LinkOptions{LinkLibC: true}); err != nil {
+		t.Fatalf("linking: %v", err)
+	}
+
+	cmd := exec.Command(exePath)
+	out, err := cmd.Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			t.Fatalf("running %s: %v", exePath, err)
+		}
+	}
+	wantOut := strconv.Itoa(sum)
+	if got := strings.TrimSpace(string(out)); got != wantOut {
+		t.Errorf("expected stdout %q, got %q", wantOut, got)
+	}
+	if cmd.ProcessState.ExitCode() != 0 {
+		t.Errorf("expected exit code 0, got %d", cmd.ProcessState.ExitCode())
+	}
+}
+
+// rightAssocAddChain builds names[0] + (names[1] + (names[2] + (... +
+// names[len-1]))), the shape that keeps the earliest operands' live
+// intervals open the longest and so is what forces the register allocator
+// to spill once there are more live vregs than physical registers.
+func rightAssocAddChain(names []string) ast.Expr {
+	e := ast.Expr(&ast.Ident{Name: names[len(names)-1]})
+	for i := len(names) - 2; i >= 0; i-- {
+		e = &ast.BinaryExpr{Op: ast.OpAdd, Left: &ast.Ident{Name: names[i]}, Right: e}
+	}
+	return e
+}
+
+// TestRunEntryTrampolineConvertsReturnValueToExitCode proves out the fix in
+// entry.go: without it, _start executed main's body directly and fell off
+// the end into a `ret` with no return address underneath it, crashing
+// instead of exiting with main's return value.
+func TestRunEntryTrampolineConvertsReturnValueToExitCode(t *testing.T) {
+	if _, err := exec.LookPath("nasm"); err != nil {
+		t.Skip("nasm not installed")
+	}
+	if _, err := exec.LookPath("ld"); err != nil {
+		t.Skip("ld not installed")
+	}
+
+	fn := &ast.FunctionDecl{
+		Name: "main",
+		Body: []ast.Stmt{&ast.ReturnStmt{Value: &ast.IntLiteral{Value: 5}}},
+	}
+
+	gen := x86.NewGeneratorWithOptions(x86.GeneratorOptions{EntryModule: true})
+	asm := gen.Generate("retcode", []*ast.FunctionDecl{fn}, nil)
+
+	dir := t.TempDir()
+	asmPath := dir + "/retcode.asm"
+	if err := os.WriteFile(asmPath, []byte(asm), 0o644); err != nil {
+		t.Fatalf("writing assembly: %v", err)
+	}
+
+	t1 := target.Default
+	objPath, err := driver.Assemble(asmPath, t1)
+	if err != nil {
+		t.Fatalf("assembling: %v", err)
+	}
+
+	exePath := dir + "/retcode"
+	if err := driver.Link([]string{objPath}, exePath, t1, driver.LinkOptions{}); err != nil {
+		t.Fatalf("linking: %v", err)
+	}
+
+	cmd := exec.Command(exePath)
+	err = cmd.Run()
+	if _, ok := err.(*exec.ExitError); err != nil && !ok {
+		t.Fatalf("running %s: %v", exePath, err)
+	}
+	if got := cmd.ProcessState.ExitCode(); got != 5 {
+		t.Errorf("expected exit code 5, got %d", got)
+	}
+}
@@ -0,0 +1,21 @@
+// Command ferret is the entry point for the Ferret compiler toolchain.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/itsfuad/ferret-compiler/compiler/cmd"
+)
+
+func main() {
+	if err := cmd.Execute(); err != nil {
+		// A program run via `ferret run` already streamed its own stderr;
+		// don't also print a line about its exit code, just propagate it.
+		if code, ok := cmd.ExitCode(err); ok {
+			os.Exit(code)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,9 @@
+// Package rt embeds Ferret's minimal runtime (rt_alloc, rt_panic) so the
+// build driver can assemble and link it into every program without
+// shipping it as a separate file the user has to manage.
+package rt
+
+import _ "embed"
+
+//go:embed runtime.asm
+var Source string